@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AlertmanagerOption func(*alertmanager)
+
+// AlertmanagerLabels 每則 alert 固定附加的 labels，例如 service、env
+func AlertmanagerLabels(labels map[string]string) AlertmanagerOption {
+	return func(a *alertmanager) {
+		for key, value := range labels {
+			a.Labels[key] = value
+		}
+	}
+}
+
+func AlertmanagerBasicAuth(username, password string) AlertmanagerOption {
+	return func(a *alertmanager) {
+		a.Username = username
+		a.Password = password
+	}
+}
+
+// Alertmanager 推送到 /api/v2/alerts，交由既有的 routing 與 silence 規則處理；
+// alertname 取 Message.Key (未設定時取 Title)，Resolved 以 endsAt 結束同一則 alert
+func (n *Notify) Alertmanager(baseURL string, opts ...AlertmanagerOption) *Notify {
+	a := &alertmanager{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Labels:  map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	n.add(a)
+	return n
+}
+
+type alertmanager struct {
+	BaseURL  string
+	Labels   map[string]string
+	Username string
+	Password string
+}
+
+func (a *alertmanager) Send(client *http.Client, message string) error {
+	return a.SendMessage(client, Message{
+		Title:    subjectOf(message),
+		Text:     message,
+		Severity: SeverityWarning,
+	})
+}
+
+func (a *alertmanager) SendMessage(client *http.Client, message Message) error {
+	labels := map[string]string{}
+	for key, value := range a.Labels {
+		labels[key] = value
+	}
+	labels["alertname"] = message.Key
+	if message.Key == "" {
+		labels["alertname"] = message.Title
+	}
+	labels["severity"] = strings.ToLower(message.Severity.String())
+
+	annotations := map[string]string{
+		"summary": message.Title,
+	}
+	if message.Text != "" {
+		annotations["description"] = message.Text
+	}
+	for _, field := range message.Fields {
+		annotations[field.Name] = field.Value
+	}
+
+	alert := map[string]interface{}{
+		"labels":      labels,
+		"annotations": annotations,
+	}
+	if message.URL != "" {
+		alert["generatorURL"] = message.URL
+	}
+	if message.Status == StatusResolved {
+		alert["endsAt"] = time.Now().UTC().Format(time.RFC3339)
+	} else {
+		alert["startsAt"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	return a.SendRaw(client, alert)
+}
+
+// SendRaw 傳入單一 postableAlert 物件
+func (a *alertmanager) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal([]interface{}{message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", a.BaseURL+"/api/v2/alerts", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.Username != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+
+	return request(client, req)
+}
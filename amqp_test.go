@@ -0,0 +1,207 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func amqpHexShort(s string) string {
+	return fmt.Sprintf("%02x", len(s)) + hex.EncodeToString([]byte(s))
+}
+
+func amqpHexLong(s string) string {
+	return fmt.Sprintf("%08x", len(s)) + hex.EncodeToString([]byte(s))
+}
+
+// amqpHexFrame frame type、channel、payload 長度、payload、frame end
+func amqpHexFrame(frameType byte, channel uint16, payload string) string {
+	return fmt.Sprintf("%02x%04x%08x", frameType, channel, len(payload)/2) + payload + "ce"
+}
+
+func amqpHexMethod(channel, class, method uint16, args string) string {
+	return amqpHexFrame(amqpFrameMethod, channel, fmt.Sprintf("%04x%04x", class, method)+args)
+}
+
+// fakeAMQPBroker 依 AMQP 0-9-1 流程回應，outcome 決定 publish 的結果 (ack、nack 或 return)
+type fakeAMQPBroker struct {
+	listener net.Listener
+	frameMax uint32
+	outcome  string
+	done     chan struct{}
+
+	mu     sync.Mutex
+	frames []string
+}
+
+func newFakeAMQPBroker(t *testing.T, frameMax uint32, outcome string) *fakeAMQPBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &fakeAMQPBroker{listener: listener, frameMax: frameMax, outcome: outcome, done: make(chan struct{})}
+	go b.serve()
+	t.Cleanup(func() { listener.Close() })
+	return b
+}
+
+func (b *fakeAMQPBroker) serve() {
+	defer close(b.done)
+	conn, err := b.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	protocol := make([]byte, 8)
+	if _, err := io.ReadFull(reader, protocol); err != nil {
+		return
+	}
+	b.record(protocol)
+
+	send := func(s string) {
+		data, _ := hex.DecodeString(s)
+		conn.Write(data)
+	}
+	// connection.start：0-9、空的 server properties、PLAIN、en_US
+	send(amqpHexMethod(0, 10, 10, "0009"+"00000000"+amqpHexLong("PLAIN")+amqpHexLong("en_US")))
+
+	var bodySize, received uint64
+	for {
+		header := make([]byte, 7)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return
+		}
+		b.record(append(header, payload...))
+
+		switch header[0] {
+		case amqpFrameHeader:
+			bodySize = binary.BigEndian.Uint64(payload[4:])
+			received = 0
+		case amqpFrameBody:
+			received += uint64(len(payload) - 1)
+			if received < bodySize {
+				continue
+			}
+			switch b.outcome {
+			case "nack":
+				send(amqpHexMethod(1, 60, 120, "0000000000000001"+"00"))
+			case "return":
+				send(amqpHexMethod(1, 60, 50, "0138"+amqpHexShort("NO_ROUTE")+amqpHexShort("events")+amqpHexShort("notify.info.firing")))
+			default:
+				send(amqpHexMethod(1, 60, 80, "0000000000000001"+"00"))
+			}
+		case amqpFrameMethod:
+			class := binary.BigEndian.Uint16(payload)
+			method := binary.BigEndian.Uint16(payload[2:])
+			switch {
+			case class == 10 && method == 11: // start-ok
+				send(amqpHexMethod(0, 10, 30, fmt.Sprintf("07ff%08x0000", b.frameMax)))
+			case class == 10 && method == 40: // open
+				send(amqpHexMethod(0, 10, 41, amqpHexShort("")))
+			case class == 20 && method == 10: // channel.open
+				send(amqpHexMethod(1, 20, 11, amqpHexLong("")))
+			case class == 85 && method == 10: // confirm.select
+				send(amqpHexMethod(1, 85, 11, ""))
+			case class == 10 && method == 50: // close
+				send(amqpHexMethod(0, 10, 51, ""))
+				return
+			}
+		}
+	}
+}
+
+func (b *fakeAMQPBroker) record(frame []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.frames = append(b.frames, hex.EncodeToString(frame))
+}
+
+func (b *fakeAMQPBroker) transcript(t *testing.T) []string {
+	select {
+	case <-b.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not close the connection")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.frames...)
+}
+
+func TestAMQPPublish(t *testing.T) {
+	broker := newFakeAMQPBroker(t, 4096, "ack")
+
+	text := strings.Repeat("x", 5000)
+	n := New().AMQP("amqp://ops:s3cret@"+broker.listener.Addr().String()+"/prod", "events")
+	if err := n.Send(map[string]interface{}{"routing_key": "ops.critical", "key": "db-1", "text": text}); err != nil {
+		t.Fatal(err)
+	}
+	payload := hex.EncodeToString([]byte(`{"key":"db-1","routing_key":"ops.critical","text":"` + text + `"}`))
+
+	// client properties 依名稱排序：capabilities {publisher_confirms: true}、product "notify-go"
+	capabilities := amqpHexShort("publisher_confirms") + "74" + "01"
+	properties := amqpHexShort("capabilities") + "46" + fmt.Sprintf("%08x", len(capabilities)/2) + capabilities +
+		amqpHexShort("product") + "53" + amqpHexLong("notify-go")
+
+	want := []string{
+		hex.EncodeToString([]byte("AMQP\x00\x00\x09\x01")),
+		amqpHexMethod(0, 10, 11, fmt.Sprintf("%08x", len(properties)/2)+properties+amqpHexShort("PLAIN")+amqpHexLong("\x00ops\x00s3cret")+amqpHexShort("en_US")),
+		amqpHexMethod(0, 10, 31, "07ff"+"00001000"+"0000"),
+		amqpHexMethod(0, 10, 40, amqpHexShort("prod")+amqpHexShort("")+"00"),
+		amqpHexMethod(1, 20, 10, "00"),
+		amqpHexMethod(1, 85, 10, "00"),
+		amqpHexMethod(1, 60, 40, "0000"+amqpHexShort("events")+amqpHexShort("ops.critical")+"01"),
+		// content header：class 60、weight 0、body size、flags (content-type、delivery-mode、message-id、timestamp)
+		amqpHexFrame(amqpFrameHeader, 1, "003c"+"0000"+fmt.Sprintf("%016x", len(payload)/2)+"90c0"+
+			amqpHexShort("application/json")+"02"+amqpHexShort("db-1")+strings.Repeat("T", 16)),
+		amqpHexFrame(amqpFrameBody, 1, payload[:4088*2]),
+		amqpHexFrame(amqpFrameBody, 1, payload[4088*2:]),
+		amqpHexMethod(0, 10, 50, "00c8"+amqpHexShort("")+"0000"+"0000"),
+	}
+
+	got := broker.transcript(t)
+	if len(got) != len(want) {
+		t.Fatalf("got %d frames, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		frame := got[i]
+		// timestamp 每次不同，以 T 取代後比對
+		if strings.Contains(want[i], "TTTT") && len(frame) > 18 {
+			frame = frame[:len(frame)-18] + strings.Repeat("T", 16) + "ce"
+		}
+		if frame != want[i] {
+			t.Errorf("frame %d =\n%s\nwant\n%s", i, frame, want[i])
+		}
+	}
+}
+
+func TestAMQPPublishRejected(t *testing.T) {
+	tests := []struct {
+		outcome string
+		want    string
+	}{
+		{"nack", "amqp broker rejected message"},
+		{"return", "amqp message returned: 312 NO_ROUTE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.outcome, func(t *testing.T) {
+			broker := newFakeAMQPBroker(t, 131072, tt.outcome)
+			err := New().AMQP("amqp://"+broker.listener.Addr().String(), "events").Send("hi")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
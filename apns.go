@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type APNsOption func(*apns)
+
+// APNsSandbox 使用開發環境 api.sandbox.push.apple.com
+func APNsSandbox() APNsOption {
+	return func(a *apns) { a.Host = "https://api.sandbox.push.apple.com" }
+}
+
+func APNsBadge(badge int) APNsOption {
+	return func(a *apns) { a.Badge = &badge }
+}
+
+func APNsSound(sound string) APNsOption {
+	return func(a *apns) { a.Sound = sound }
+}
+
+// APNs 使用 p8 金鑰 (token-based) 驗證；APNs 只接受 HTTP/2，自訂 Client.Transport 時需支援 HTTP/2
+func (n *Notify) APNs(p8Key []byte, keyID, teamID, bundleID string, deviceTokens []string, opts ...APNsOption) *Notify {
+	a := &apns{
+		KeyID:        keyID,
+		TeamID:       teamID,
+		BundleID:     bundleID,
+		DeviceTokens: deviceTokens,
+		Host:         "https://api.push.apple.com",
+		Sound:        "default",
+	}
+	a.Key, a.err = parsePrivateKey(p8Key)
+	for _, opt := range opts {
+		opt(a)
+	}
+	n.add(a)
+	return n
+}
+
+type apns struct {
+	Key          crypto.Signer
+	KeyID        string
+	TeamID       string
+	BundleID     string
+	DeviceTokens []string
+	Host         string
+	Badge        *int
+	Sound        string
+
+	err      error
+	mu       sync.Mutex
+	jwt      string
+	issuedAt time.Time
+}
+
+func (a *apns) Send(client *http.Client, message string) error {
+	return a.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+func (a *apns) SendMessage(client *http.Client, message Message) error {
+	aps := map[string]interface{}{
+		"alert": map[string]interface{}{
+			"title": message.Title,
+			"body":  message.Text,
+		},
+	}
+	if a.Badge != nil {
+		aps["badge"] = *a.Badge
+	}
+	if a.Sound != "" {
+		aps["sound"] = a.Sound
+	}
+	if message.Severity >= SeverityCritical {
+		aps["interruption-level"] = "time-sensitive"
+	}
+
+	payload := map[string]interface{}{"aps": aps}
+	if message.URL != "" {
+		payload["url"] = message.URL
+	}
+	if message.Key != "" {
+		payload["key"] = message.Key
+	}
+
+	return a.push(client, payload, apnsCollapseID(message.Key))
+}
+
+// apnsCollapseID APNs 限制 apns-collapse-id 不超過 64 bytes，過長的 Key 改用 SHA-256 (剛好 64 個十六進位字元)
+func apnsCollapseID(key string) string {
+	if len(key) <= 64 {
+		return key
+	}
+	return sha256Hex([]byte(key))
+}
+
+// SendRaw 傳入完整 payload（含 aps 字典）
+func (a *apns) SendRaw(client *http.Client, message map[string]interface{}) error {
+	return a.push(client, message, "")
+}
+
+func (a *apns) push(client *http.Client, payload map[string]interface{}, collapseID string) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	token, err := a.token()
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	var errs []error
+	for _, deviceToken := range a.DeviceTokens {
+		req, err := http.NewRequest("POST", a.Host+"/3/device/"+deviceToken, bytes.NewReader(jsonData))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "bearer "+token)
+		req.Header.Set("apns-topic", a.BundleID)
+		req.Header.Set("apns-push-type", "alert")
+		req.Header.Set("apns-priority", "10")
+		if collapseID != "" {
+			req.Header.Set("apns-collapse-id", collapseID)
+		}
+
+		if err := request(client, req); err != nil {
+			errs = append(errs, fmt.Errorf("apns %s: %w", deviceToken, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// token Apple 要求 provider token 每 20~60 分鐘更新一次
+func (a *apns) token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.jwt != "" && time.Since(a.issuedAt) < 50*time.Minute {
+		return a.jwt, nil
+	}
+
+	now := time.Now()
+	jwt, err := signJWT(
+		map[string]interface{}{"kid": a.KeyID},
+		map[string]interface{}{"iss": a.TeamID, "iat": now.Unix()},
+		a.Key,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	a.jwt = jwt
+	a.issuedAt = now
+	return a.jwt, nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPNsCollapseID(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	var ids []string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("apns-collapse-id"))
+	}))
+	defer done()
+
+	n.APNs(p8, "KEYID", "TEAMID", "com.example.app", []string{"device"})
+	long := "uptime/https://status.example.com/" + strings.Repeat("a", 64)
+	for _, key := range []string{"db-1", long} {
+		if err := n.Send(Message{Title: "down", Key: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ids[0] != "db-1" || ids[1] != sha256Hex([]byte(long)) || len(ids[1]) != 64 {
+		t.Fatalf("collapse ids = %q", ids)
+	}
+}
@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+)
+
+// Approval 審核結果
+type Approval struct {
+	Approved bool
+	User     string
+	UserID   string
+	Platform string
+}
+
+type ApprovalOption func(*approvalWaiter)
+
+// ApprovalApprovers 限制可核准或拒絕的使用者，格式為 "平台:使用者 ID"，例如 "telegram:123456789"、
+// "discord:80351110224678912" 或 "line:U4af4980629..."；未設定時任何能按下按鈕的人都可決定
+func ApprovalApprovers(users ...string) ApprovalOption {
+	return func(w *approvalWaiter) {
+		if w.approvers == nil {
+			w.approvers = map[string]bool{}
+		}
+		for _, user := range users {
+			w.approvers[user] = true
+		}
+	}
+}
+
+type approvalWaiter struct {
+	result    chan Interaction
+	approvers map[string]bool
+}
+
+func (w *approvalWaiter) allowed(in Interaction) bool {
+	return len(w.approvers) == 0 || (in.UserID != "" && w.approvers[in.Platform+":"+in.UserID])
+}
+
+// supportsActions 會將 Message.Actions 轉成按鈕的 notifier
+func supportsActions(notifier INotify) bool {
+	switch notifier.(type) {
+	case *telegram, *discord, *line:
+		return true
+	}
+	return false
+}
+
+// Interactive 設定接收按鈕回呼的 Interactions，RequestApproval 需要先設定
+func (n *Notify) Interactive(i *Interactions) *Notify {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.interactions = i
+	return n
+}
+
+// RequestApproval 送出附有 Approve / Deny 按鈕的訊息，阻塞直到有人決定或 ctx 逾時，
+// 例如 ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)；
+// 只要有一個支援按鈕的通道 (Telegram、Discord、LINE) 送達就繼續等待，其餘通道的錯誤僅記錄；
+// 全部失敗時回傳錯誤，呼叫端應視為未核准
+func (n *Notify) RequestApproval(ctx context.Context, message Message, opts ...ApprovalOption) (Approval, error) {
+	n.mu.RLock()
+	i := n.interactions
+	n.mu.RUnlock()
+	if i == nil {
+		return Approval{}, errors.New("interactions not configured, call Interactive first")
+	}
+
+	// 每次審核需要獨立的 key 才能對應回這次等待
+	id := make([]byte, 8)
+	rand.Read(id)
+	if message.Key == "" {
+		message.Key = "approval"
+	}
+	message.Key += "/" + hex.EncodeToString(id)
+	message.Actions = []Action{ApproveAction, DenyAction}
+
+	waiter := &approvalWaiter{result: make(chan Interaction, 1)}
+	for _, opt := range opts {
+		opt(waiter)
+	}
+	i.mu.Lock()
+	i.waiters[message.Key] = waiter
+	i.mu.Unlock()
+	defer func() {
+		i.mu.Lock()
+		delete(i.waiters, message.Key)
+		i.mu.Unlock()
+	}()
+
+	var errs []error
+	delivered := false
+	for _, notifier := range n.notifiers() {
+		id, err := sendMessageID(n.Client, notifier, message)
+		n.record(notifier, message, id, err)
+		if err != nil {
+			log.Println("notify send error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if supportsActions(notifier) {
+			delivered = true
+		}
+	}
+	if !delivered {
+		if len(errs) > 0 {
+			return Approval{}, errors.Join(errs...)
+		}
+		return Approval{}, errors.New("no notifier with button support (Telegram, Discord, LINE) configured")
+	}
+
+	select {
+	case in := <-waiter.result:
+		return Approval{
+			Approved: in.Action == ApproveAction.ID,
+			User:     in.User,
+			UserID:   in.UserID,
+			Platform: in.Platform,
+		}, nil
+	case <-ctx.Done():
+		return Approval{}, ctx.Err()
+	}
+}
+
+// RequestApprovalFunc 同 RequestApproval，但於背景等待並在決定或逾時後呼叫 fn
+func (n *Notify) RequestApprovalFunc(ctx context.Context, message Message, fn func(Approval, error), opts ...ApprovalOption) {
+	go func() {
+		fn(n.RequestApproval(ctx, message, opts...))
+	}()
+}
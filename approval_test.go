@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// approvalNotify Telegram 通道記錄按鈕的 callback_data，另加上一個必定失敗的通道
+func approvalNotify(t *testing.T) (*Notify, *Interactions, chan string) {
+	keys := make(chan string, 1)
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ReplyMarkup struct {
+				InlineKeyboard [][]struct {
+					CallbackData string `json:"callback_data"`
+				} `json:"inline_keyboard"`
+			} `json:"reply_markup"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		_, key, _ := parseActionData(body.ReplyMarkup.InlineKeyboard[0][0].CallbackData)
+		keys <- key
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	t.Cleanup(done)
+
+	i := NewInteractions()
+	n.Notifiers = append(n.Notifiers, &recordNotifier{err: errors.New("boom")})
+	n.Telegram("token", "42").Interactive(i)
+	return n, i, keys
+}
+
+func TestRequestApprovalPartialFailure(t *testing.T) {
+	n, i, keys := approvalNotify(t)
+
+	go func() {
+		i.dispatch(Interaction{Platform: "telegram", Action: "approve", Key: <-keys, User: "alice", UserID: "1"})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	approval, err := n.RequestApproval(ctx, Message{Title: "deploy?"})
+	if err != nil || !approval.Approved || approval.UserID != "1" {
+		t.Fatalf("approval = %+v, err = %v", approval, err)
+	}
+}
+
+func TestRequestApprovalNoButtonNotifier(t *testing.T) {
+	n := New().Interactive(NewInteractions())
+	n.Notifiers = append(n.Notifiers, &recordNotifier{})
+	_, err := n.RequestApproval(context.Background(), Message{Title: "deploy?"})
+	if err == nil || !strings.Contains(err.Error(), "no notifier with button support") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRequestApprovalApprovers(t *testing.T) {
+	n, i, keys := approvalNotify(t)
+
+	result := make(chan error, 1)
+	go func() {
+		key := <-keys
+		// 名稱相同但 ID 不符的使用者不可決定，請求仍保留給核准者
+		if _, err := i.dispatch(Interaction{Platform: "telegram", Action: "approve", Key: key, User: "alice", UserID: "2"}); err == nil {
+			result <- errors.New("unlisted user was allowed to approve")
+			return
+		}
+		if _, err := i.dispatch(Interaction{Platform: "discord", Action: "approve", Key: key, User: "alice", UserID: "1"}); err == nil {
+			result <- errors.New("approver on another platform was allowed to approve")
+			return
+		}
+		_, err := i.dispatch(Interaction{Platform: "telegram", Action: "deny", Key: key, User: "alice", UserID: "1"})
+		result <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	approval, err := n.RequestApproval(ctx, Message{Title: "deploy?"}, ApprovalApprovers("telegram:1"))
+	if err != nil || approval.Approved || approval.UserID != "1" {
+		t.Fatalf("approval = %+v, err = %v", approval, err)
+	}
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+}
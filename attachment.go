@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+)
+
+func postMultipart(client *http.Client, url string, fields map[string]string, fileField string, attachment Attachment, header http.Header) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	part, err := writer.CreateFormFile(fileField, attachment.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create multipart: %v", err)
+	}
+	if _, err := part.Write(attachment.Data); err != nil {
+		return fmt.Errorf("failed to write multipart: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return request(client, req)
+}
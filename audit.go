@@ -0,0 +1,255 @@
+package notify
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord 一則訊息送往單一 notifier 的紀錄
+type AuditRecord struct {
+	Time time.Time
+	// Target notifier 種類，例如 telegram、discord、webhook
+	Target  string
+	Message Message
+	// Error 送出失敗時的錯誤訊息，成功時為空
+	Error string
+	// ProviderID 平台回傳的訊息 ID，目前支援 Telegram、Discord、LINE 的結構化訊息
+	ProviderID string
+}
+
+// AuditFilter 查詢條件，零值欄位不篩選
+type AuditFilter struct {
+	Since  time.Time
+	Until  time.Time
+	Target string
+	Key    string
+	// Failed 只列出送出失敗的紀錄
+	Failed bool
+	// Limit 最多回傳幾筆，預設 100
+	Limit int
+}
+
+func (f AuditFilter) match(record AuditRecord) bool {
+	return (f.Since.IsZero() || !record.Time.Before(f.Since)) &&
+		(f.Until.IsZero() || record.Time.Before(f.Until)) &&
+		(f.Target == "" || record.Target == f.Target) &&
+		(f.Key == "" || record.Message.Key == f.Key) &&
+		(!f.Failed || record.Error != "")
+}
+
+func (f AuditFilter) limit() int {
+	if f.Limit <= 0 {
+		return 100
+	}
+	return f.Limit
+}
+
+// AuditStore 保存送出紀錄，History 依時間由新到舊回傳
+type AuditStore interface {
+	Record(AuditRecord) error
+	History(AuditFilter) ([]AuditRecord, error)
+}
+
+// Audit 紀錄之後每則訊息送往各 notifier 的結果，附件只保留檔名
+func (n *Notify) Audit(store AuditStore) *Notify {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.audit = store
+	return n
+}
+
+func (n *Notify) auditStore() AuditStore {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.audit
+}
+
+// History 查詢 Audit 設定的紀錄
+func (n *Notify) History(filter AuditFilter) ([]AuditRecord, error) {
+	audit := n.auditStore()
+	if audit == nil {
+		return nil, errors.New("audit store not configured, call Audit first")
+	}
+	return audit.History(filter)
+}
+
+func (n *Notify) record(notifier INotify, message Message, providerID string, err error) {
+	audit := n.auditStore()
+	if audit == nil {
+		return
+	}
+
+	if len(message.Attachments) > 0 {
+		attachments := make([]Attachment, len(message.Attachments))
+		for i, attachment := range message.Attachments {
+			attachments[i] = Attachment{Name: attachment.Name}
+		}
+		message.Attachments = attachments
+	}
+
+	record := AuditRecord{
+		Time:       time.Now(),
+		Target:     targetName(notifier),
+		Message:    message,
+		ProviderID: providerID,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if err := audit.Record(record); err != nil {
+		log.Println("notify audit error", err)
+	}
+}
+
+// targetName 由型別名稱取得 notifier 種類，例如 *notify.telegram 為 telegram
+func targetName(notifier INotify) string {
+	name := fmt.Sprintf("%T", notifier)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// MemoryAudit 保存在記憶體的 AuditStore，超過上限時捨棄最舊的紀錄
+type MemoryAudit struct {
+	max int
+
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+// NewMemoryAudit max 小於等於 0 時保留 1000 筆
+func NewMemoryAudit(max int) *MemoryAudit {
+	if max <= 0 {
+		max = 1000
+	}
+	return &MemoryAudit{max: max}
+}
+
+func (m *MemoryAudit) Record(record AuditRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records = append(m.records, record)
+	if len(m.records) > m.max {
+		m.records = append([]AuditRecord(nil), m.records[len(m.records)-m.max:]...)
+	}
+	return nil
+}
+
+func (m *MemoryAudit) History(filter AuditFilter) ([]AuditRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []AuditRecord
+	for i := len(m.records) - 1; i >= 0 && len(out) < filter.limit(); i-- {
+		if filter.match(m.records[i]) {
+			out = append(out, m.records[i])
+		}
+	}
+	return out, nil
+}
+
+// SQLiteAudit 以 SQLite 保存的 AuditStore；本套件不引入 driver，
+// 請自行 import 例如 modernc.org/sqlite 或 github.com/mattn/go-sqlite3 後以 sql.Open 開啟
+type SQLiteAudit struct {
+	db *sql.DB
+}
+
+// NewSQLiteAudit 建立 notify_audit 資料表 (若不存在)
+func NewSQLiteAudit(db *sql.DB) (*SQLiteAudit, error) {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS notify_audit (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			time        INTEGER NOT NULL,
+			target      TEXT NOT NULL,
+			message_key TEXT NOT NULL,
+			message     TEXT NOT NULL,
+			error       TEXT NOT NULL,
+			provider_id TEXT NOT NULL
+		)`,
+		"CREATE INDEX IF NOT EXISTS notify_audit_time ON notify_audit (time)",
+		"CREATE INDEX IF NOT EXISTS notify_audit_key ON notify_audit (message_key)",
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return nil, fmt.Errorf("failed to create audit table: %v", err)
+		}
+	}
+	return &SQLiteAudit{db: db}, nil
+}
+
+func (s *SQLiteAudit) Record(record AuditRecord) error {
+	message, err := json.Marshal(record.Message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	_, err = s.db.Exec(
+		"INSERT INTO notify_audit (time, target, message_key, message, error, provider_id) VALUES (?, ?, ?, ?, ?, ?)",
+		record.Time.UnixNano(), record.Target, record.Message.Key, string(message), record.Error, record.ProviderID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit record: %v", err)
+	}
+	return nil
+}
+
+func (s *SQLiteAudit) History(filter AuditFilter) ([]AuditRecord, error) {
+	var where []string
+	var args []interface{}
+	if !filter.Since.IsZero() {
+		where = append(where, "time >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, "time < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+	if filter.Target != "" {
+		where = append(where, "target = ?")
+		args = append(args, filter.Target)
+	}
+	if filter.Key != "" {
+		where = append(where, "message_key = ?")
+		args = append(args, filter.Key)
+	}
+	if filter.Failed {
+		where = append(where, "error != ''")
+	}
+
+	query := "SELECT time, target, message, error, provider_id FROM notify_audit"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY time DESC, id DESC LIMIT ?"
+	args = append(args, filter.limit())
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %v", err)
+	}
+	defer rows.Close()
+
+	var out []AuditRecord
+	for rows.Next() {
+		var record AuditRecord
+		var at int64
+		var message string
+		if err := rows.Scan(&at, &record.Target, &message, &record.Error, &record.ProviderID); err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %v", err)
+		}
+		if err := json.Unmarshal([]byte(message), &record.Message); err != nil {
+			return nil, fmt.Errorf("failed to decode audit message: %v", err)
+		}
+		record.Time = time.Unix(0, at)
+		out = append(out, record)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,341 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expires         time.Time
+}
+
+func (c *awsCredentials) expired() bool {
+	return !c.Expires.IsZero() && time.Until(c.Expires) < 5*time.Minute
+}
+
+// awsSigner 以 SigV4 簽署請求，未指定金鑰時依序從環境變數、共用設定檔、ECS 與 EC2 metadata 取得憑證
+type awsSigner struct {
+	Region  string
+	Service string
+	Static  *awsCredentials
+
+	mu    sync.Mutex
+	cache *awsCredentials
+}
+
+func newAWSSigner(region, service string) *awsSigner {
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return &awsSigner{Region: region, Service: service}
+}
+
+func (s *awsSigner) credentials(client *http.Client) (*awsCredentials, error) {
+	if s.Static != nil {
+		return s.Static, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache != nil && !s.cache.expired() {
+		return s.cache, nil
+	}
+
+	providers := []func(*http.Client) (*awsCredentials, error){
+		awsEnvCredentials,
+		awsSharedCredentials,
+		awsContainerCredentials,
+		awsInstanceCredentials,
+	}
+	for _, provider := range providers {
+		creds, err := provider(client)
+		if err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			s.cache = creds
+			return creds, nil
+		}
+	}
+
+	return nil, errors.New("no AWS credentials found")
+}
+
+func awsEnvCredentials(*http.Client) (*awsCredentials, error) {
+	id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if id == "" || secret == "" {
+		return nil, nil
+	}
+	return &awsCredentials{
+		AccessKeyID:     id,
+		SecretAccessKey: secret,
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil
+}
+
+func awsSharedCredentials(*http.Client) (*awsCredentials, error) {
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+
+	profile := os.Getenv("AWS_PROFILE")
+	if profile == "" {
+		profile = "default"
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil
+	}
+	defer f.Close()
+
+	creds := &awsCredentials{}
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			continue
+		}
+		if section != profile {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			creds.AccessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			creds.SecretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			creds.SessionToken = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read AWS credentials file: %v", err)
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+func awsContainerCredentials(client *http.Client) (*awsCredentials, error) {
+	url := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); uri != "" {
+		url = "http://169.254.170.2" + uri
+	}
+	if url == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"); token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	return awsFetchCredentials(client, req)
+}
+
+func awsInstanceCredentials(client *http.Client) (*awsCredentials, error) {
+	if strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		return nil, nil
+	}
+
+	const endpoint = "http://169.254.169.254/latest"
+	metadata := &http.Client{Transport: client.Transport, Timeout: 2 * time.Second}
+
+	req, err := http.NewRequest("PUT", endpoint+"/api/token", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+	token, err := awsMetadataGet(metadata, req)
+	if err != nil {
+		// 不在 EC2 上時直接略過
+		return nil, nil
+	}
+
+	req, err = http.NewRequest("GET", endpoint+"/meta-data/iam/security-credentials/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+	role, err := awsMetadataGet(metadata, req)
+	if err != nil || role == "" {
+		return nil, nil
+	}
+	role, _, _ = strings.Cut(role, "\n")
+
+	req, err = http.NewRequest("GET", endpoint+"/meta-data/iam/security-credentials/"+role, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+
+	return awsFetchCredentials(metadata, req)
+}
+
+func awsMetadataGet(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata responded with status: %v", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func awsFetchCredentials(client *http.Client, req *http.Request) (*awsCredentials, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AWS credentials: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AWS credentials endpoint responded with status: %v", resp.Status)
+	}
+
+	var result struct {
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string `json:"SecretAccessKey"`
+		Token           string `json:"Token"`
+		Expiration      time.Time
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode AWS credentials: %v", err)
+	}
+
+	return &awsCredentials{
+		AccessKeyID:     result.AccessKeyID,
+		SecretAccessKey: result.SecretAccessKey,
+		SessionToken:    result.Token,
+		Expires:         result.Expiration,
+	}, nil
+}
+
+func (s *awsSigner) sign(client *http.Client, req *http.Request, body []byte) error {
+	creds, err := s.credentials(client)
+	if err != nil {
+		return err
+	}
+	s.signAt(req, body, creds, time.Now())
+	return nil
+}
+
+// signAt 以指定時間簽署，X-Amz-Content-Sha256 只有 S3 需要，其他服務不送
+func (s *awsSigner) signAt(req *http.Request, body []byte, creds *awsCredentials, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	query := strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		query,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.Region, s.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s.Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
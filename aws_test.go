@@ -0,0 +1,238 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAWSSignAt 以 AWS 公開的 SigV4 test suite 與 IAM 文件範例驗證簽章
+func TestAWSSignAt(t *testing.T) {
+	suite := &awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	at := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		service     string
+		method      string
+		url         string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:    "get-vanilla",
+			service: "service",
+			method:  "GET",
+			url:     "https://example.amazonaws.com/",
+			want:    "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:    "post-vanilla",
+			service: "service",
+			method:  "POST",
+			url:     "https://example.amazonaws.com/",
+			want:    "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:    "get-vanilla-query-order-key-case",
+			service: "service",
+			method:  "GET",
+			url:     "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			want:    "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:        "post-x-www-form-urlencoded",
+			service:     "service",
+			method:      "POST",
+			url:         "https://example.amazonaws.com/",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "Param1=value1",
+			want:        "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+		{
+			name:        "iam-list-users",
+			service:     "iam",
+			method:      "GET",
+			url:         "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			contentType: "application/x-www-form-urlencoded; charset=utf-8",
+			want:        "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			signer := &awsSigner{Region: "us-east-1", Service: tt.service}
+			signer.signAt(req, []byte(tt.body), suite, at)
+
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Fatalf("X-Amz-Date = %q", got)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Fatalf("Authorization =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAWSSignAtSessionToken(t *testing.T) {
+	req, _ := http.NewRequest("POST", "https://sns.us-east-1.amazonaws.com/", nil)
+	creds := &awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "token"}
+	(&awsSigner{Region: "us-east-1", Service: "sns"}).signAt(req, nil, creds, time.Now())
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "token" {
+		t.Fatalf("X-Amz-Security-Token = %q", got)
+	}
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Fatalf("session token not signed: %s", got)
+	}
+}
+
+func TestSNSSend(t *testing.T) {
+	var form url.Values
+	var auth, host string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		auth = r.Header.Get("Authorization")
+		host = r.Host
+	}))
+	defer done()
+
+	n.SNS("arn:aws:sns:ap-northeast-1:123456789012:alerts",
+		SNSSubject("deploy"),
+		SNSAttribute("env", "prod"),
+		SNSCredentials("AKIDEXAMPLE", "secret", ""),
+	)
+	if err := n.Send("disk full"); err != nil {
+		t.Fatal(err)
+	}
+
+	if host != "sns.ap-northeast-1.amazonaws.com" {
+		t.Fatalf("host = %q", host)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(auth, "/ap-northeast-1/sns/aws4_request") {
+		t.Fatalf("Authorization = %q", auth)
+	}
+	want := map[string]string{
+		"Action":                         "Publish",
+		"TopicArn":                       "arn:aws:sns:ap-northeast-1:123456789012:alerts",
+		"Message":                        "disk full",
+		"Subject":                        "deploy",
+		"MessageAttributes.entry.1.Name": "env",
+		"MessageAttributes.entry.1.Value.StringValue": "prod",
+	}
+	for key, value := range want {
+		if got := form.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestSESSend(t *testing.T) {
+	var body map[string]interface{}
+	var auth, host, path string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		auth = r.Header.Get("Authorization")
+		host, path = r.Host, r.URL.Path
+	}))
+	defer done()
+
+	n.SES("eu-west-1", "alerts@example.com", []string{"ops@example.com"},
+		SESSubject("deploy"),
+		SESConfigurationSet("tracking"),
+		SESCredentials("AKIDEXAMPLE", "secret", ""),
+	)
+	if err := n.Send("disk full"); err != nil {
+		t.Fatal(err)
+	}
+
+	if host != "email.eu-west-1.amazonaws.com" || path != "/v2/email/outbound-emails" {
+		t.Fatalf("request to %s%s", host, path)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(auth, "/eu-west-1/ses/aws4_request") {
+		t.Fatalf("Authorization = %q", auth)
+	}
+	want := `{"ConfigurationSetName":"tracking","Content":{"Simple":{"Body":{"Text":{"Charset":"UTF-8","Data":"disk full"}},"Subject":{"Charset":"UTF-8","Data":"deploy"}}},"Destination":{"ToAddresses":["ops@example.com"]},"FromEmailAddress":"alerts@example.com"}`
+	if got, _ := json.Marshal(body); string(got) != want {
+		t.Fatalf("body = %s", got)
+	}
+}
+
+func TestSQSSendFIFO(t *testing.T) {
+	var body map[string]interface{}
+	var auth, target string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		auth = r.Header.Get("Authorization")
+		target = r.Header.Get("X-Amz-Target")
+	}))
+	defer done()
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/alerts.fifo"
+	n.SQS(queueURL,
+		SQSMessageGroup("deploys"),
+		SQSAttribute("env", "prod"),
+		SQSCredentials("AKIDEXAMPLE", "secret", ""),
+	)
+	if err := n.Send("disk full"); err != nil {
+		t.Fatal(err)
+	}
+
+	if target != "AmazonSQS.SendMessage" || !strings.Contains(auth, "/us-east-1/sqs/aws4_request") {
+		t.Fatalf("X-Amz-Target = %q, Authorization = %q", target, auth)
+	}
+	want := map[string]interface{}{
+		"QueueUrl":               queueURL,
+		"MessageBody":            "disk full",
+		"MessageGroupId":         "deploys",
+		"MessageDeduplicationId": sha256Hex([]byte("disk full")),
+		"MessageAttributes": map[string]interface{}{
+			"env": map[string]interface{}{"DataType": "String", "StringValue": "prod"},
+		},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Fatalf("body = %v", body)
+	}
+}
+
+func TestSQSDeduplicationID(t *testing.T) {
+	var ids []string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		id, _ := body["MessageDeduplicationId"].(string)
+		ids = append(ids, id)
+	}))
+	defer done()
+
+	n.SQS("https://sqs.us-east-1.amazonaws.com/123456789012/alerts.fifo", SQSCredentials("AKIDEXAMPLE", "secret", ""))
+	long := "uptime/https://example.com/" + strings.Repeat("a", 200)
+	for _, message := range []Message{
+		{Key: "db-1", Text: "down"},
+		{Key: "db-1", Text: "up", Status: StatusResolved},
+		{Key: long},
+		{Key: "disk full on db-1"},
+	} {
+		if err := n.Send(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"db-1/firing", "db-1/resolved", sha256Hex([]byte(long + "/firing")), sha256Hex([]byte("disk full on db-1/firing"))}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %q, want %q", ids, want)
+	}
+}
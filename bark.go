@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	BarkActive        = "active"
+	BarkTimeSensitive = "timeSensitive"
+	BarkPassive       = "passive"
+	BarkCritical      = "critical"
+)
+
+type BarkOption func(*bark)
+
+// BarkServer 自架 bark-server 時指定位址，預設 https://api.day.app
+func BarkServer(serverURL string) BarkOption {
+	return func(b *bark) { b.ServerURL = strings.TrimRight(serverURL, "/") }
+}
+
+func BarkTitle(title string) BarkOption {
+	return func(b *bark) { b.Title = title }
+}
+
+func BarkGroup(group string) BarkOption {
+	return func(b *bark) { b.Group = group }
+}
+
+func BarkSound(sound string) BarkOption {
+	return func(b *bark) { b.Sound = sound }
+}
+
+func BarkURL(url string) BarkOption {
+	return func(b *bark) { b.URL = url }
+}
+
+func BarkLevel(level string) BarkOption {
+	return func(b *bark) { b.Level = level }
+}
+
+func (n *Notify) Bark(deviceKey string, opts ...BarkOption) *Notify {
+	b := &bark{
+		ServerURL: "https://api.day.app",
+		DeviceKey: deviceKey,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	n.add(b)
+	return n
+}
+
+type bark struct {
+	ServerURL string
+	DeviceKey string
+	Title     string
+	Group     string
+	Sound     string
+	URL       string
+	Level     string
+}
+
+func (b *bark) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{
+		"body": message,
+	}
+	for key, value := range map[string]string{
+		"title": b.Title,
+		"group": b.Group,
+		"sound": b.Sound,
+		"url":   b.URL,
+		"level": b.Level,
+	} {
+		if value != "" {
+			payload[key] = value
+		}
+	}
+
+	return b.SendRaw(client, payload)
+}
+
+func (b *bark) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["device_key"]; !ok {
+		message["device_key"] = b.DeviceKey
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", b.ServerURL+"/push", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return request(client, req)
+}
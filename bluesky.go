@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"]+[^\s<>".,;:!?)\]]`)
+
+type BlueskyOption func(*bluesky)
+
+// BlueskyPDS 自架 PDS 時指定位址，預設 https://bsky.social
+func BlueskyPDS(serviceURL string) BlueskyOption {
+	return func(b *bluesky) { b.ServiceURL = strings.TrimRight(serviceURL, "/") }
+}
+
+// Bluesky 使用 app password 登入，請勿使用帳號主密碼
+func (n *Notify) Bluesky(handle, appPassword string, opts ...BlueskyOption) *Notify {
+	b := &bluesky{
+		ServiceURL:  "https://bsky.social",
+		Handle:      handle,
+		AppPassword: appPassword,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	n.add(b)
+	return n
+}
+
+type bluesky struct {
+	ServiceURL  string
+	Handle      string
+	AppPassword string
+
+	mu        sync.Mutex
+	did       string
+	accessJwt string
+	createdAt time.Time
+}
+
+func (b *bluesky) Send(client *http.Client, message string) error {
+	// 貼文上限 300 字
+	if r := []rune(message); len(r) > 300 {
+		message = string(r[:299]) + "…"
+	}
+
+	post := map[string]interface{}{
+		"$type":     "app.bsky.feed.post",
+		"text":      message,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// facet 的位置以 UTF-8 byte offset 計算
+	var facets []map[string]interface{}
+	for _, loc := range linkPattern.FindAllStringIndex(message, -1) {
+		facets = append(facets, map[string]interface{}{
+			"index": map[string]interface{}{"byteStart": loc[0], "byteEnd": loc[1]},
+			"features": []map[string]interface{}{{
+				"$type": "app.bsky.richtext.facet#link",
+				"uri":   message[loc[0]:loc[1]],
+			}},
+		})
+	}
+	if len(facets) > 0 {
+		post["facets"] = facets
+	}
+
+	return b.SendRaw(client, post)
+}
+
+// SendRaw 傳入 app.bsky.feed.post record
+func (b *bluesky) SendRaw(client *http.Client, message map[string]interface{}) error {
+	did, token, err := b.session(client)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := message["$type"]; !ok {
+		message["$type"] = "app.bsky.feed.post"
+	}
+	if _, ok := message["createdAt"]; !ok {
+		message["createdAt"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"repo":       did,
+		"collection": "app.bsky.feed.post",
+		"record":     message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", b.ServiceURL+"/xrpc/com.atproto.repo.createRecord", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return request(client, req)
+}
+
+func (b *bluesky) session(client *http.Client) (string, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// accessJwt 約兩小時到期，提早重新登入
+	if b.accessJwt != "" && time.Since(b.createdAt) < 90*time.Minute {
+		return b.did, b.accessJwt, nil
+	}
+
+	jsonData, err := json.Marshal(map[string]string{
+		"identifier": b.Handle,
+		"password":   b.AppPassword,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", b.ServiceURL+"/xrpc/com.atproto.server.createSession", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		DID       string `json:"did"`
+		AccessJwt string `json:"accessJwt"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", "", fmt.Errorf("failed to create bluesky session: %v", err)
+	}
+
+	b.did = result.DID
+	b.accessJwt = result.AccessJwt
+	b.createdAt = time.Now()
+	return b.did, b.accessJwt, nil
+}
@@ -0,0 +1,274 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type BuildStatus int
+
+const (
+	BuildStarted BuildStatus = iota
+	BuildSucceeded
+	BuildFailed
+	BuildCanceled
+)
+
+func (s BuildStatus) String() string {
+	switch s {
+	case BuildStarted:
+		return "started"
+	case BuildSucceeded:
+		return "succeeded"
+	case BuildFailed:
+		return "failed"
+	case BuildCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+func (s BuildStatus) emoji() string {
+	switch s {
+	case BuildSucceeded:
+		return "✅"
+	case BuildFailed:
+		return "❌"
+	case BuildCanceled:
+		return "⚪"
+	default:
+		return "🔄"
+	}
+}
+
+// Build CI 建置或部署的狀態，由 SendBuild 轉成各平台原生格式
+type Build struct {
+	// Name pipeline 或 workflow 名稱，例如 "deploy"
+	Name          string
+	Repo          string
+	Branch        string
+	Commit        string
+	CommitMessage string
+	// CommitURL 未指定時不連結 commit
+	CommitURL   string
+	Author      string
+	Environment string
+	Status      BuildStatus
+	Duration    time.Duration
+	// URL pipeline 頁面
+	URL string
+}
+
+func (b Build) title() string {
+	name := b.Name
+	if name == "" {
+		name = "Build"
+	}
+	title := fmt.Sprintf("%s %s %s", b.Status.emoji(), name, b.Status)
+	if b.Repo != "" {
+		title += ": " + b.Repo
+	}
+	if b.Environment != "" {
+		title += " → " + b.Environment
+	}
+	return title
+}
+
+func (b Build) shortCommit() string {
+	if len(b.Commit) > 7 {
+		return b.Commit[:7]
+	}
+	return b.Commit
+}
+
+func (b Build) fields() []Field {
+	var fields []Field
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, Field{Name: name, Value: value})
+		}
+	}
+	add("Branch", b.Branch)
+	add("Commit", b.shortCommit())
+	add("Author", b.Author)
+	if b.Duration > 0 {
+		add("Duration", b.Duration.Round(time.Second).String())
+	}
+	return fields
+}
+
+// Message 轉成結構化訊息，供沒有專屬格式的 notifier 使用
+func (b Build) Message() Message {
+	severity := SeverityInfo
+	switch b.Status {
+	case BuildFailed:
+		severity = SeverityError
+	case BuildCanceled:
+		severity = SeverityWarning
+	}
+
+	return Message{
+		Title:    b.title(),
+		Text:     firstLine(b.CommitMessage),
+		Severity: severity,
+		Fields:   b.fields(),
+		URL:      b.URL,
+		Key:      strings.Join([]string{"build", b.Repo, b.Name, b.Branch, b.Environment}, "/"),
+	}
+}
+
+func (b Build) color() int {
+	switch b.Status {
+	case BuildSucceeded:
+		return 0x2eb886
+	case BuildFailed:
+		return 0xd00000
+	case BuildCanceled:
+		return 0x9e9e9e
+	default:
+		return 0x439fe0
+	}
+}
+
+// DiscordEmbed Discord 訊息 payload (embed)
+func (b Build) DiscordEmbed() map[string]interface{} {
+	var fields []map[string]interface{}
+	for _, field := range b.fields() {
+		fields = append(fields, map[string]interface{}{"name": field.Name, "value": field.Value, "inline": true})
+	}
+
+	embed := map[string]interface{}{
+		"title":     b.title(),
+		"color":     b.color(),
+		"fields":    fields,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if b.URL != "" {
+		embed["url"] = b.URL
+	}
+	if b.CommitMessage != "" {
+		embed["description"] = firstLine(b.CommitMessage)
+	}
+	return map[string]interface{}{"embeds": []interface{}{embed}}
+}
+
+// TelegramHTML Telegram sendMessage payload (parse_mode HTML)
+func (b Build) TelegramHTML() map[string]interface{} {
+	lines := []string{"<b>" + html.EscapeString(b.title()) + "</b>"}
+	if b.CommitMessage != "" {
+		lines = append(lines, "<i>"+html.EscapeString(firstLine(b.CommitMessage))+"</i>")
+	}
+	for _, field := range b.fields() {
+		value := "<code>" + html.EscapeString(field.Value) + "</code>"
+		if field.Name == "Commit" && b.CommitURL != "" {
+			value = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(b.CommitURL), html.EscapeString(field.Value))
+		}
+		lines = append(lines, html.EscapeString(field.Name)+": "+value)
+	}
+	if b.URL != "" {
+		lines = append(lines, fmt.Sprintf(`<a href="%s">View pipeline</a>`, html.EscapeString(b.URL)))
+	}
+
+	return map[string]interface{}{
+		"text":                     strings.Join(lines, "\n"),
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}
+}
+
+// TeamsCard Microsoft Teams Workflows/incoming webhook 的 Adaptive Card payload
+func (b Build) TeamsCard() map[string]interface{} {
+	color := "Accent"
+	switch b.Status {
+	case BuildSucceeded:
+		color = "Good"
+	case BuildFailed:
+		color = "Attention"
+	case BuildCanceled:
+		color = "Warning"
+	}
+
+	var facts []map[string]interface{}
+	for _, field := range b.fields() {
+		facts = append(facts, map[string]interface{}{"title": field.Name, "value": field.Value})
+	}
+
+	body := []interface{}{
+		map[string]interface{}{"type": "TextBlock", "text": b.title(), "weight": "Bolder", "size": "Medium", "color": color, "wrap": true},
+	}
+	if b.CommitMessage != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": firstLine(b.CommitMessage), "isSubtle": true, "wrap": true})
+	}
+	body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if b.URL != "" {
+		card["actions"] = []interface{}{
+			map[string]interface{}{"type": "Action.OpenUrl", "title": "View pipeline", "url": b.URL},
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
+
+// SendBuild 依 notifier 選擇格式：Discord 使用 embed、Telegram 使用 HTML、
+// 指向 Teams 的 Webhook 使用 Adaptive Card，其餘使用 Build.Message()
+func (n *Notify) SendBuild(b Build) error {
+	message := b.Message()
+
+	var errs []error
+	for _, notifier := range n.notifiers() {
+		var err error
+		switch t := notifier.(type) {
+		case *discord:
+			err = t.SendRaw(n.Client, b.DiscordEmbed())
+		case *telegram:
+			err = t.SendRaw(n.Client, b.TelegramHTML())
+		case *webhook:
+			if isTeamsWebhook(t.URL) && t.Template == nil && t.Form == nil {
+				err = t.SendRaw(n.Client, b.TeamsCard())
+			} else {
+				err = sendMessage(n.Client, t, message)
+			}
+		default:
+			err = sendMessage(n.Client, notifier, message)
+		}
+		n.record(notifier, message, "", err)
+		if err != nil {
+			log.Println("notify send error", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isTeamsWebhook(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return strings.HasSuffix(host, ".webhook.office.com") || strings.HasSuffix(host, ".logic.azure.com") ||
+		strings.HasSuffix(host, ".powerplatform.com")
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
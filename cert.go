@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CertOption func(*CertMonitor)
+
+// CertThresholds 剩餘天數到達哪些門檻時通知，預設 30、14、7、1 天
+func CertThresholds(days ...int) CertOption {
+	return func(m *CertMonitor) { m.Thresholds = days }
+}
+
+// CertInterval 檢查間隔，預設 12 小時
+func CertInterval(interval time.Duration) CertOption {
+	return func(m *CertMonitor) { m.Interval = interval }
+}
+
+// CertExpiry 檢查主機 ("example.com" 或 "example.com:8443") 的 TLS 憑證，
+// 剩餘天數到達各門檻時通知一次，憑證驗證失敗時也會通知；更新憑證後送出 Resolved
+func (n *Notify) CertExpiry(hosts []string, opts ...CertOption) *CertMonitor {
+	m := &CertMonitor{
+		Hosts:      hosts,
+		Thresholds: []int{30, 14, 7, 1},
+		Interval:   12 * time.Hour,
+		notify:     n,
+		notified:   map[string]int{},
+		invalid:    map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(m.Thresholds)))
+	return m
+}
+
+type CertMonitor struct {
+	Hosts      []string
+	Thresholds []int
+	Interval   time.Duration
+
+	notify *Notify
+	// notified 各主機已通知過的最小門檻
+	notified map[string]int
+	invalid  map[string]bool
+}
+
+// Run 依間隔檢查直到 ctx 結束
+func (m *CertMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, host := range m.Hosts {
+			if err := m.Check(ctx, host); err != nil {
+				log.Println("notify cert check error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 檢查單一主機；無法連線時回傳錯誤 (不通知，交給 Uptime 等監控)
+func (m *CertMonitor) Check(ctx context.Context, host string) error {
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(host, "443")
+	}
+	serverName, _, _ := net.SplitHostPort(address)
+
+	var verifyErr error
+	cert, err := fetchCertificate(ctx, address, serverName, false)
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		if !errors.As(err, &certErr) {
+			return fmt.Errorf("%s: %v", host, err)
+		}
+		// 驗證失敗時仍取得憑證以回報到期日
+		verifyErr = err
+		cert, err = fetchCertificate(ctx, address, serverName, true)
+		if err != nil {
+			return fmt.Errorf("%s: %v", host, err)
+		}
+	}
+
+	key := "cert/" + host
+	daysLeft := int(time.Until(cert.NotAfter).Hours() / 24)
+	fields := []Field{
+		{Name: "expires", Value: cert.NotAfter.UTC().Format("2006-01-02 15:04 MST")},
+		{Name: "days left", Value: strconv.Itoa(daysLeft)},
+	}
+	if cert.Issuer.CommonName != "" {
+		fields = append(fields, Field{Name: "issuer", Value: cert.Issuer.CommonName})
+	}
+	if len(cert.DNSNames) > 0 {
+		fields = append(fields, Field{Name: "names", Value: strings.Join(cert.DNSNames, ", ")})
+	}
+
+	if verifyErr != nil {
+		if !m.invalid[host] {
+			m.invalid[host] = true
+			m.notify.Send(Message{
+				Title:    "Certificate verification failed: " + host,
+				Text:     verifyErr.Error(),
+				Severity: SeverityError,
+				Fields:   fields,
+				Key:      key + "/verify",
+			})
+		}
+	} else if m.invalid[host] {
+		delete(m.invalid, host)
+		m.notify.Send(Message{
+			Title:    "Certificate valid again: " + host,
+			Severity: SeverityInfo,
+			Status:   StatusResolved,
+			Fields:   fields,
+			Key:      key + "/verify",
+		})
+	}
+
+	threshold, crossed := m.threshold(daysLeft)
+	previous, notified := m.notified[host]
+	if !crossed {
+		if notified {
+			delete(m.notified, host)
+			m.notify.Send(Message{
+				Title:    "Certificate renewed: " + host,
+				Severity: SeverityInfo,
+				Status:   StatusResolved,
+				Fields:   fields,
+				Key:      key,
+			})
+		}
+		return nil
+	}
+	if notified && previous <= threshold {
+		return nil
+	}
+	m.notified[host] = threshold
+
+	title := fmt.Sprintf("Certificate expires in %d days: %s", daysLeft, host)
+	severity := SeverityWarning
+	switch {
+	case daysLeft < 0:
+		title = "Certificate expired: " + host
+		severity = SeverityCritical
+	case daysLeft <= 1:
+		severity = SeverityCritical
+	case daysLeft <= 7:
+		severity = SeverityError
+	}
+	m.notify.Send(Message{
+		Title:    title,
+		Severity: severity,
+		Fields:   fields,
+		Key:      key,
+	})
+	return nil
+}
+
+// threshold 回傳 daysLeft 已到達的最小門檻
+func (m *CertMonitor) threshold(daysLeft int) (int, bool) {
+	threshold, crossed := 0, false
+	for _, days := range m.Thresholds {
+		if daysLeft <= days {
+			threshold, crossed = days, true
+		}
+	}
+	return threshold, crossed
+}
+
+func fetchCertificate(ctx context.Context, address, serverName string, insecure bool) (*x509.Certificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	dialer := &tls.Dialer{Config: &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: insecure,
+	}}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate presented")
+	}
+	return certs[0], nil
+}
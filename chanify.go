@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+type ChanifyOption func(*chanify)
+
+// ChanifyServer 自架節點位址，預設 https://api.chanify.net
+func ChanifyServer(serverURL string) ChanifyOption {
+	return func(c *chanify) { c.ServerURL = strings.TrimRight(serverURL, "/") }
+}
+
+func ChanifyTitle(title string) ChanifyOption {
+	return func(c *chanify) { c.Title = title }
+}
+
+// ChanifyLink 訊息附上連結，文字內容照常送出
+func ChanifyLink(url string) ChanifyOption {
+	return func(c *chanify) { c.Link = url }
+}
+
+// ChanifyActions 每個動作格式為 "名稱|網址"
+func ChanifyActions(actions ...string) ChanifyOption {
+	return func(c *chanify) { c.Actions = append(c.Actions, actions...) }
+}
+
+func (n *Notify) Chanify(token string, opts ...ChanifyOption) *Notify {
+	c := &chanify{
+		ServerURL: "https://api.chanify.net",
+		Token:     token,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	n.add(c)
+	return n
+}
+
+type chanify struct {
+	ServerURL string
+	Token     string
+	Title     string
+	Link      string
+	Actions   []string
+}
+
+func (c *chanify) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{
+		"text": message,
+	}
+	if c.Link != "" {
+		payload["link"] = c.Link
+	}
+	if c.Title != "" {
+		payload["title"] = c.Title
+	}
+	if len(c.Actions) > 0 {
+		payload["actions"] = c.Actions
+	}
+
+	return c.SendRaw(client, payload)
+}
+
+// SendRaw 若 image 欄位為 []byte 則以 multipart 上傳圖片
+func (c *chanify) SendRaw(client *http.Client, message map[string]interface{}) error {
+	url := fmt.Sprintf("%s/v1/sender/%s", c.ServerURL, c.Token)
+
+	if image, ok := message["image"].([]byte); ok {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("image", "image")
+		if err != nil {
+			return fmt.Errorf("failed to create multipart: %v", err)
+		}
+		if _, err := part.Write(image); err != nil {
+			return fmt.Errorf("failed to write multipart: %v", err)
+		}
+		for key, value := range message {
+			if key != "image" {
+				writer.WriteField(key, fmt.Sprint(value))
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("failed to close multipart: %v", err)
+		}
+
+		req, err := http.NewRequest("POST", url, body)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		return request(client, req)
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestChanifyLinkKeepsText(t *testing.T) {
+	var got map[string]interface{}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sender/token" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer done()
+
+	n.Chanify("token", ChanifyLink("https://status.example.com"))
+	if err := n.Send("db down"); err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "db down" || got["link"] != "https://status.example.com" {
+		t.Fatalf("payload = %v", got)
+	}
+}
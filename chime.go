@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ChimeOption func(*chime)
+
+// ChimeMentionAll 提醒聊天室所有成員
+func ChimeMentionAll() ChimeOption {
+	return func(c *chime) { c.Mention = "@All" }
+}
+
+// ChimeMentionPresent 只提醒目前在線的成員
+func ChimeMentionPresent() ChimeOption {
+	return func(c *chime) { c.Mention = "@Present" }
+}
+
+func ChimeMarkdown() ChimeOption {
+	return func(c *chime) { c.Markdown = true }
+}
+
+func (n *Notify) Chime(webhookURL string, opts ...ChimeOption) *Notify {
+	c := &chime{WebhookURL: webhookURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	n.add(c)
+	return n
+}
+
+type chime struct {
+	WebhookURL string
+	Mention    string
+	Markdown   bool
+}
+
+func (c *chime) Send(client *http.Client, message string) error {
+	if c.Mention != "" {
+		message = c.Mention + " " + message
+	}
+	// 以 /md 開頭的內容會以 markdown 呈現
+	if c.Markdown {
+		message = "/md " + message
+	}
+
+	return c.SendRaw(client, map[string]interface{}{"Content": message})
+}
+
+func (c *chime) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", c.WebhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
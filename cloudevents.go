@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CloudEventsType 送出事件的 type 屬性
+const CloudEventsType = "io.github.gps-gaming.notify.message"
+
+// CloudEvents 以 HTTP binary mode 將每則通知送到 CloudEvents sink (例如 Knative Broker)，
+// source 為事件來源 URI，例如 "/services/billing"
+func (n *Notify) CloudEvents(sinkURL, source string) *Notify {
+	n.add(&cloudEvents{
+		SinkURL: sinkURL,
+		Source:  source,
+	})
+	return n
+}
+
+type cloudEvents struct {
+	SinkURL string
+	Source  string
+}
+
+func (c *cloudEvents) Send(client *http.Client, message string) error {
+	return c.SendMessage(client, Message{Text: message})
+}
+
+// SendMessage Key 作為 subject，severity 與 status 以 extension 屬性帶出方便 trigger 過濾
+func (c *cloudEvents) SendMessage(client *http.Client, message Message) error {
+	record := messageRecord(message)
+	record.Timestamp = time.Now().UTC()
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	return c.post(client, jsonData, map[string]string{
+		"subject":  message.Key,
+		"severity": strings.ToLower(record.Severity),
+		"status":   strings.ToLower(record.Status),
+	})
+}
+
+// SendRaw 支援 {"type", "subject", "data"} 等 CloudEvents 屬性，其餘欄位作為 extension
+func (c *cloudEvents) SendRaw(client *http.Client, message map[string]interface{}) error {
+	data, ok := message["data"]
+	if !ok {
+		data = map[string]interface{}{}
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	attributes := map[string]string{}
+	for key, value := range message {
+		if key != "data" {
+			attributes[strings.ToLower(key)] = fmt.Sprint(value)
+		}
+	}
+	return c.post(client, jsonData, attributes)
+}
+
+func (c *cloudEvents) post(client *http.Client, data []byte, attributes map[string]string) error {
+	req, err := http.NewRequest("POST", c.SinkURL, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", "1.0")
+	req.Header.Set("ce-id", hex.EncodeToString(id))
+	req.Header.Set("ce-source", c.Source)
+	req.Header.Set("ce-type", CloudEventsType)
+	req.Header.Set("ce-time", time.Now().UTC().Format(time.RFC3339Nano))
+	for key, value := range attributes {
+		if value != "" {
+			req.Header.Set("ce-"+key, value)
+		}
+	}
+
+	return request(client, req)
+}
@@ -0,0 +1,438 @@
+// notify 命令列工具，讓 shell script 與 cron job 不必撰寫 Go 即可送出通知
+//
+//	notify send --provider telegram --severity crit "disk full on db-1"
+//	df -h | notify send --title "disk usage"
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+const usage = `Usage:
+  notify send [flags] [message...]   送出通知，未提供 message 時讀取 stdin
+  notify providers                   列出支援的 provider 與設定欄位
+
+設定檔依序尋找 --config、$NOTIFY_CONFIG、./notify.json、<user config dir>/notify/config.json：
+
+  {"providers": {
+    "telegram": {"bot_token": "...", "chat_id": "..."},
+    "oncall":   {"type": "pagerduty", "routing_key": "..."}
+  }}
+
+各欄位也可用環境變數提供或覆寫，例如 NOTIFY_TELEGRAM_BOT_TOKEN、NOTIFY_ONCALL_ROUTING_KEY。
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "send":
+		if err := send(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "notify:", err)
+			var usageErr usageError
+			if errors.As(err, &usageErr) {
+				os.Exit(2)
+			}
+			os.Exit(1)
+		}
+	case "providers":
+		listProviders()
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+type usageError struct{ error }
+
+func send(args []string) error {
+	flags := flag.NewFlagSet("send", flag.ContinueOnError)
+	providerNames := flags.String("provider", "", "逗號分隔的 provider 名稱，預設為設定檔中的全部")
+	severity := flags.String("severity", "info", "info、warn、error、crit")
+	status := flags.String("status", "firing", "firing、ack、resolved")
+	title := flags.String("title", "", "標題，預設為訊息第一行")
+	key := flags.String("key", "", "事件 key，用於去重或更新同一事件")
+	link := flags.String("url", "", "附加連結")
+	configPath := flags.String("config", "", "設定檔路徑")
+	if err := flags.Parse(args); err != nil {
+		return usageError{err}
+	}
+
+	text := strings.Join(flags.Args(), " ")
+	if text == "" || text == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %v", err)
+		}
+		text = strings.TrimRight(string(data), "\n")
+	}
+	if text == "" && *title == "" {
+		return usageError{errors.New("message is required")}
+	}
+
+	message := notify.Message{
+		Title: *title,
+		Text:  text,
+		URL:   *link,
+		Key:   *key,
+	}
+	if message.Title == "" {
+		message.Title, message.Text, _ = strings.Cut(text, "\n")
+	}
+	var err error
+	if message.Severity, err = notify.ParseSeverity(*severity); err != nil {
+		return usageError{err}
+	}
+	if message.Status, err = notify.ParseStatus(*status); err != nil {
+		return usageError{err}
+	}
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if *providerNames != "" {
+		for _, name := range strings.Split(*providerNames, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+	} else {
+		for name := range config {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		return usageError{errors.New("no providers configured, use --provider or a config file")}
+	}
+
+	n := notify.New()
+	for _, name := range names {
+		if err := configure(n, name, config[name]); err != nil {
+			return usageError{err}
+		}
+	}
+	return n.Send(message)
+}
+
+// loadConfig 找不到設定檔時回傳空設定，仍可完全以環境變數設定
+func loadConfig(path string) (map[string]map[string]string, error) {
+	candidates := []string{path, os.Getenv("NOTIFY_CONFIG"), "notify.json"}
+	if dir, err := os.UserConfigDir(); err == nil {
+		candidates = append(candidates, filepath.Join(dir, "notify", "config.json"))
+	}
+
+	for i, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		data, err := os.ReadFile(candidate)
+		if errors.Is(err, os.ErrNotExist) && i > 1 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %v", err)
+		}
+
+		var config struct {
+			Providers map[string]map[string]interface{} `json:"providers"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", candidate, err)
+		}
+
+		out := map[string]map[string]string{}
+		for name, values := range config.Providers {
+			out[name] = map[string]string{}
+			for key, value := range values {
+				switch v := value.(type) {
+				case string:
+					out[name][key] = v
+				case []interface{}:
+					var items []string
+					for _, item := range v {
+						items = append(items, fmt.Sprint(item))
+					}
+					out[name][key] = strings.Join(items, ",")
+				default:
+					out[name][key] = fmt.Sprint(v)
+				}
+			}
+		}
+		return out, nil
+	}
+	return map[string]map[string]string{}, nil
+}
+
+func configure(n *notify.Notify, name string, values map[string]string) error {
+	s := &settings{name: name, values: values}
+	kind := s.get("type")
+	if kind == "" {
+		kind = name
+	}
+
+	provider, ok := providers[strings.ToLower(kind)]
+	if !ok {
+		return fmt.Errorf("unknown provider %q, run `notify providers` for the list", kind)
+	}
+	provider.build(n, s)
+
+	if len(s.missing) > 0 {
+		var hints []string
+		for _, key := range s.missing {
+			hints = append(hints, fmt.Sprintf("%s (%s)", key, s.env(key)))
+		}
+		return fmt.Errorf("provider %s: missing %s", name, strings.Join(hints, ", "))
+	}
+	return nil
+}
+
+type settings struct {
+	name    string
+	values  map[string]string
+	missing []string
+}
+
+func (s *settings) env(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, s.name)
+	return strings.ToUpper("NOTIFY_" + name + "_" + key)
+}
+
+// get 環境變數優先於設定檔
+func (s *settings) get(key string) string {
+	if value, ok := os.LookupEnv(s.env(key)); ok {
+		return value
+	}
+	return s.values[key]
+}
+
+func (s *settings) require(key string) string {
+	value := s.get(key)
+	if value == "" {
+		s.missing = append(s.missing, key)
+	}
+	return value
+}
+
+// list 逗號分隔的多個值
+func (s *settings) list(key string) []string {
+	var out []string
+	for _, item := range strings.Split(s.require(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+type provider struct {
+	keys  string
+	build func(n *notify.Notify, s *settings)
+}
+
+var providers = map[string]provider{
+	"telegram": {"bot_token chat_id", func(n *notify.Notify, s *settings) {
+		n.Telegram(s.require("bot_token"), s.require("chat_id"))
+	}},
+	"line": {"bot_token chat_id", func(n *notify.Notify, s *settings) {
+		n.Line(s.require("bot_token"), s.require("chat_id"))
+	}},
+	"discord": {"bot_token channel_id", func(n *notify.Notify, s *settings) {
+		n.Discord(s.require("bot_token"), s.require("channel_id"))
+	}},
+	"googlechat": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.GoogleChat(s.require("webhook_url"))
+	}},
+	"webhook": {"url", func(n *notify.Notify, s *settings) {
+		n.Webhook(s.require("url"))
+	}},
+	"ntfy": {"server_url topic", func(n *notify.Notify, s *settings) {
+		n.Ntfy(s.require("server_url"), s.require("topic"))
+	}},
+	"gotify": {"server_url app_token", func(n *notify.Notify, s *settings) {
+		n.Gotify(s.require("server_url"), s.require("app_token"))
+	}},
+	"pushover": {"app_token user_key", func(n *notify.Notify, s *settings) {
+		n.Pushover(s.require("app_token"), s.require("user_key"))
+	}},
+	"pushbullet": {"access_token", func(n *notify.Notify, s *settings) {
+		n.Pushbullet(s.require("access_token"))
+	}},
+	"bark": {"device_key", func(n *notify.Notify, s *settings) {
+		n.Bark(s.require("device_key"))
+	}},
+	"serverchan": {"send_key", func(n *notify.Notify, s *settings) {
+		n.ServerChan(s.require("send_key"))
+	}},
+	"pushdeer": {"push_key", func(n *notify.Notify, s *settings) {
+		n.PushDeer(s.require("push_key"))
+	}},
+	"chanify": {"token", func(n *notify.Notify, s *settings) {
+		n.Chanify(s.require("token"))
+	}},
+	"matrix": {"homeserver_url access_token room_id", func(n *notify.Notify, s *settings) {
+		n.Matrix(s.require("homeserver_url"), s.require("access_token"), s.require("room_id"))
+	}},
+	"rocketchat": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.RocketChatWebhook(s.require("webhook_url"))
+	}},
+	"zulip": {"site_url bot_email api_key stream topic", func(n *notify.Notify, s *settings) {
+		n.Zulip(s.require("site_url"), s.require("bot_email"), s.require("api_key"), s.require("stream"), s.require("topic"))
+	}},
+	"dingtalk": {"access_token", func(n *notify.Notify, s *settings) {
+		n.DingTalk(s.require("access_token"))
+	}},
+	"wecom": {"webhook_key", func(n *notify.Notify, s *settings) {
+		n.WeCom(s.require("webhook_key"))
+	}},
+	"feishu": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.Feishu(s.require("webhook_url"))
+	}},
+	"webex": {"bot_token target", func(n *notify.Notify, s *settings) {
+		n.Webex(s.require("bot_token"), s.require("target"))
+	}},
+	"chime": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.Chime(s.require("webhook_url"))
+	}},
+	"flock": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.Flock(s.require("webhook_url"))
+	}},
+	"pagerduty": {"routing_key", func(n *notify.Notify, s *settings) {
+		n.PagerDuty(s.require("routing_key"))
+	}},
+	"opsgenie": {"api_key [region=eu]", func(n *notify.Notify, s *settings) {
+		var opts []notify.OpsgenieOption
+		if strings.EqualFold(s.get("region"), "eu") {
+			opts = append(opts, notify.OpsgenieEU())
+		}
+		n.Opsgenie(s.require("api_key"), opts...)
+	}},
+	"splunkoncall": {"api_key routing_key", func(n *notify.Notify, s *settings) {
+		n.SplunkOnCall(s.require("api_key"), s.require("routing_key"))
+	}},
+	"grafanaoncall": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.GrafanaOnCall(s.require("webhook_url"))
+	}},
+	"squadcast": {"webhook_url", func(n *notify.Notify, s *settings) {
+		n.Squadcast(s.require("webhook_url"))
+	}},
+	"alertmanager": {"url", func(n *notify.Notify, s *settings) {
+		n.Alertmanager(s.require("url"))
+	}},
+	"cloudevents": {"url source", func(n *notify.Notify, s *settings) {
+		n.CloudEvents(s.require("url"), s.require("source"))
+	}},
+	"mailgun": {"api_key domain from to", func(n *notify.Notify, s *settings) {
+		n.Mailgun(s.require("api_key"), s.require("domain"), s.require("from"), s.list("to"))
+	}},
+	"postmark": {"server_token from to", func(n *notify.Notify, s *settings) {
+		n.Postmark(s.require("server_token"), s.require("from"), s.list("to"))
+	}},
+	"ses": {"region from to", func(n *notify.Notify, s *settings) {
+		n.SES(s.require("region"), s.require("from"), s.list("to"))
+	}},
+	"sns": {"topic_arn", func(n *notify.Notify, s *settings) {
+		n.SNS(s.require("topic_arn"))
+	}},
+	"sqs": {"queue_url", func(n *notify.Notify, s *settings) {
+		n.SQS(s.require("queue_url"))
+	}},
+	"twilio": {"account_sid auth_token from to", func(n *notify.Notify, s *settings) {
+		n.Twilio(s.require("account_sid"), s.require("auth_token"), s.require("from"), s.list("to"))
+	}},
+	"vonage": {"api_key api_secret from to", func(n *notify.Notify, s *settings) {
+		n.Vonage(s.require("api_key"), s.require("api_secret"), s.require("from"), s.list("to"))
+	}},
+	"messagebird": {"access_key originator recipients", func(n *notify.Notify, s *settings) {
+		n.MessageBird(s.require("access_key"), s.require("originator"), s.list("recipients"))
+	}},
+	"signal": {"api_url number recipients", func(n *notify.Notify, s *settings) {
+		n.Signal(s.require("api_url"), s.require("number"), s.list("recipients"))
+	}},
+	"mastodon": {"instance_url access_token", func(n *notify.Notify, s *settings) {
+		n.Mastodon(s.require("instance_url"), s.require("access_token"))
+	}},
+	"bluesky": {"handle app_password", func(n *notify.Notify, s *settings) {
+		n.Bluesky(s.require("handle"), s.require("app_password"))
+	}},
+	"irc": {"server nick channel", func(n *notify.Notify, s *settings) {
+		n.IRC(s.require("server"), s.require("nick"), s.require("channel"))
+	}},
+	"xmpp": {"jid password recipients", func(n *notify.Notify, s *settings) {
+		n.XMPP(s.require("jid"), s.require("password"), s.list("recipients"))
+	}},
+	"healthchecks": {"ping_url", func(n *notify.Notify, s *settings) {
+		n.Healthchecks(s.require("ping_url"))
+	}},
+	"cronitor": {"api_key monitor_key", func(n *notify.Notify, s *settings) {
+		n.Cronitor(s.require("api_key"), s.require("monitor_key"))
+	}},
+	"deadmanssnitch": {"snitch", func(n *notify.Notify, s *settings) {
+		n.DeadMansSnitch(s.require("snitch"))
+	}},
+	"sentry": {"dsn", func(n *notify.Notify, s *settings) {
+		n.Sentry(s.require("dsn"))
+	}},
+	"github": {"token repo", func(n *notify.Notify, s *settings) {
+		n.GitHub(s.require("token"), s.require("repo"))
+	}},
+	"gitlab": {"token project", func(n *notify.Notify, s *settings) {
+		n.GitLab(s.require("token"), s.require("project"))
+	}},
+	"syslog": {"[network address]", func(n *notify.Notify, s *settings) {
+		n.Syslog(s.get("network"), s.get("address"))
+	}},
+	"desktop": {"", func(n *notify.Notify, s *settings) {
+		n.Desktop()
+	}},
+	"file": {"path", func(n *notify.Notify, s *settings) {
+		n.File(s.require("path"))
+	}},
+	"nats": {"url subject", func(n *notify.Notify, s *settings) {
+		n.NATS(s.require("url"), s.require("subject"))
+	}},
+	"mqtt": {"url topic", func(n *notify.Notify, s *settings) {
+		n.MQTT(s.require("url"), s.require("topic"))
+	}},
+	"redis": {"url channel", func(n *notify.Notify, s *settings) {
+		n.Redis(s.require("url"), s.require("channel"))
+	}},
+	"amqp": {"url exchange", func(n *notify.Notify, s *settings) {
+		n.AMQP(s.require("url"), s.require("exchange"))
+	}},
+	"kafka": {"brokers topic", func(n *notify.Notify, s *settings) {
+		n.Kafka(s.list("brokers"), s.require("topic"))
+	}},
+}
+
+func listProviders() {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%-16s %s\n", name, providers[name].keys)
+	}
+}
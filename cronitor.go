@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Cronitor 使用 telemetry API 回報工作狀態，apiKey 為 Telemetry Key
+func (n *Notify) Cronitor(apiKey, monitorKey string) *Notify {
+	n.add(&cronitor{
+		APIKey:     apiKey,
+		MonitorKey: monitorKey,
+	})
+	return n
+}
+
+type cronitor struct {
+	APIKey     string
+	MonitorKey string
+}
+
+// Send Cronitor 沒有只記錄的 ping，純文字視為完成
+func (c *cronitor) Send(client *http.Client, message string) error {
+	return c.ping(client, "complete", "", message)
+}
+
+// SendMessage Error / Critical 回報 fail，其餘回報 complete，Key 作為 series 對應同一次執行
+func (c *cronitor) SendMessage(client *http.Client, message Message) error {
+	state := "complete"
+	if message.Status != StatusResolved && message.Severity >= SeverityError {
+		state = "fail"
+	}
+	return c.ping(client, state, message.Key, message.String())
+}
+
+// SendRaw 支援 {"state": "run"|"complete"|"fail"|"ok", "series": ..., "message": ...}
+func (c *cronitor) SendRaw(client *http.Client, message map[string]interface{}) error {
+	state, ok := message["state"].(string)
+	if !ok {
+		state = "complete"
+	}
+	series, _ := message["series"].(string)
+	text, _ := message["message"].(string)
+	return c.ping(client, state, series, text)
+}
+
+func (c *cronitor) ping(client *http.Client, state, series, message string) error {
+	query := url.Values{}
+	query.Set("state", state)
+	if series != "" {
+		query.Set("series", series)
+	}
+	if message != "" {
+		// Cronitor 訊息上限 2000 字
+		if r := []rune(message); len(r) > 2000 {
+			message = string(r[:2000])
+		}
+		query.Set("message", message)
+	}
+
+	url := fmt.Sprintf("https://cronitor.link/p/%s/%s?%s", c.APIKey, url.PathEscape(c.MonitorKey), query.Encode())
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	return request(client, req)
+}
+
+// DeadMansSnitch snitch 為完整網址 (https://nosnch.in/<token>) 或 token
+func (n *Notify) DeadMansSnitch(snitch string) *Notify {
+	if !strings.HasPrefix(snitch, "https://") && !strings.HasPrefix(snitch, "http://") {
+		snitch = "https://nosnch.in/" + snitch
+	}
+	n.add(&deadMansSnitch{URL: snitch})
+	return n
+}
+
+type deadMansSnitch struct {
+	URL string
+}
+
+func (d *deadMansSnitch) Send(client *http.Client, message string) error {
+	return d.checkIn(client, "0", message)
+}
+
+// SendMessage Error / Critical 以非零結束代碼回報失敗
+func (d *deadMansSnitch) SendMessage(client *http.Client, message Message) error {
+	status := "0"
+	if message.Status != StatusResolved && message.Severity >= SeverityError {
+		status = "1"
+	}
+	return d.checkIn(client, status, message.String())
+}
+
+// SendRaw 支援 {"s": 結束代碼, "m": 訊息}
+func (d *deadMansSnitch) SendRaw(client *http.Client, message map[string]interface{}) error {
+	status := "0"
+	if s, ok := message["s"]; ok {
+		status = fmt.Sprint(s)
+	}
+	text, _ := message["m"].(string)
+	return d.checkIn(client, status, text)
+}
+
+func (d *deadMansSnitch) checkIn(client *http.Client, status, message string) error {
+	form := url.Values{}
+	form.Set("s", status)
+	if message != "" {
+		form.Set("m", message)
+	}
+
+	req, err := http.NewRequest("POST", d.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return request(client, req)
+}
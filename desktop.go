@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+type DesktopOption func(*desktop)
+
+// DesktopAppName 通知顯示的應用程式名稱
+func DesktopAppName(name string) DesktopOption {
+	return func(d *desktop) { d.AppName = name }
+}
+
+// DesktopIcon 圖示路徑，僅 Linux 支援
+func DesktopIcon(icon string) DesktopOption {
+	return func(d *desktop) { d.Icon = icon }
+}
+
+// Desktop 本機桌面通知：Linux 使用 notify-send、macOS 使用 osascript、Windows 使用 PowerShell toast
+func (n *Notify) Desktop(opts ...DesktopOption) *Notify {
+	d := &desktop{AppName: "notify"}
+	for _, opt := range opts {
+		opt(d)
+	}
+	n.add(d)
+	return n
+}
+
+type desktop struct {
+	AppName string
+	Icon    string
+}
+
+func (d *desktop) Send(_ *http.Client, message string) error {
+	title, body, _ := strings.Cut(message, "\n")
+	return d.notify(title, body, "normal")
+}
+
+func (d *desktop) SendMessage(_ *http.Client, message Message) error {
+	urgency := "normal"
+	switch message.Severity {
+	case SeverityInfo:
+		urgency = "low"
+	case SeverityCritical:
+		urgency = "critical"
+	}
+	if message.Status == StatusResolved {
+		urgency = "low"
+	}
+
+	title := message.Title
+	if message.Status != StatusFiring {
+		title = fmt.Sprintf("[%s] %s", message.Status, title)
+	}
+	return d.notify(title, message.Text, urgency)
+}
+
+// SendRaw 支援 {"title", "body", "urgency"}
+func (d *desktop) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	title, _ := message["title"].(string)
+	body, _ := message["body"].(string)
+	urgency, ok := message["urgency"].(string)
+	if !ok {
+		urgency = "normal"
+	}
+	return d.notify(title, body, urgency)
+}
+
+func (d *desktop) notify(title, body, urgency string) error {
+	if title == "" {
+		title = d.AppName
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		args := []string{"--app-name", d.AppName, "--urgency", urgency}
+		if d.Icon != "" {
+			args = append(args, "--icon", d.Icon)
+		}
+		args = append(args, "--", title, body)
+		cmd = exec.Command("notify-send", args...)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s subtitle %s",
+			appleScriptString(body), appleScriptString(d.AppName), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript)
+		// 透過環境變數傳遞內容，避免跳脫問題
+		cmd.Env = append(cmd.Environ(), "NOTIFY_APP="+d.AppName, "NOTIFY_TITLE="+title, "NOTIFY_BODY="+body)
+	default:
+		return fmt.Errorf("desktop notification not supported on %s", runtime.GOOS)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show desktop notification: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func appleScriptString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+const windowsToastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$texts = $template.GetElementsByTagName("text")
+$texts.Item(0).AppendChild($template.CreateTextNode($env:NOTIFY_TITLE)) | Out-Null
+$texts.Item(1).AppendChild($template.CreateTextNode($env:NOTIFY_BODY)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:NOTIFY_APP).Show($toast)
+`
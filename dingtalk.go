@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DingTalkOption func(*dingtalk)
+
+// DingTalkSecret 機器人啟用「加簽」時的密鑰
+func DingTalkSecret(secret string) DingTalkOption {
+	return func(d *dingtalk) { d.Secret = secret }
+}
+
+func DingTalkAtMobiles(mobiles ...string) DingTalkOption {
+	return func(d *dingtalk) { d.AtMobiles = append(d.AtMobiles, mobiles...) }
+}
+
+func DingTalkAtUserIDs(userIDs ...string) DingTalkOption {
+	return func(d *dingtalk) { d.AtUserIDs = append(d.AtUserIDs, userIDs...) }
+}
+
+func DingTalkAtAll() DingTalkOption {
+	return func(d *dingtalk) { d.AtAll = true }
+}
+
+// DingTalkMarkdown 純文字訊息改以 markdown 類型送出
+func DingTalkMarkdown() DingTalkOption {
+	return func(d *dingtalk) { d.Markdown = true }
+}
+
+func (n *Notify) DingTalk(accessToken string, opts ...DingTalkOption) *Notify {
+	d := &dingtalk{AccessToken: accessToken}
+	for _, opt := range opts {
+		opt(d)
+	}
+	n.add(d)
+	return n
+}
+
+type dingtalk struct {
+	AccessToken string
+	Secret      string
+	AtMobiles   []string
+	AtUserIDs   []string
+	AtAll       bool
+	Markdown    bool
+}
+
+func (d *dingtalk) Send(client *http.Client, message string) error {
+	if d.Markdown {
+		return d.SendRaw(client, map[string]interface{}{
+			"msgtype": "markdown",
+			"markdown": map[string]interface{}{
+				"title": subjectOf(message),
+				"text":  message + d.mentions(),
+			},
+		})
+	}
+
+	return d.SendRaw(client, map[string]interface{}{
+		"msgtype": "text",
+		"text":    map[string]interface{}{"content": message},
+	})
+}
+
+func (d *dingtalk) SendMessage(client *http.Client, message Message) error {
+	var lines []string
+	heading := message.heading()
+	if heading != "" {
+		lines = append(lines, "### "+heading)
+	}
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("- **%s**: %s", field.Name, field.Value))
+	}
+	text := strings.Join(lines, "\n\n")
+
+	// title 顯示於通知列表，與內文標題一致
+	title := heading
+	if title == "" {
+		title = subjectOf(message.Text)
+	}
+
+	if message.URL != "" {
+		return d.SendRaw(client, map[string]interface{}{
+			"msgtype": "actionCard",
+			"actionCard": map[string]interface{}{
+				"title":       title,
+				"text":        text + d.mentions(),
+				"singleTitle": "查看詳情",
+				"singleURL":   message.URL,
+			},
+		})
+	}
+
+	return d.SendRaw(client, map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]interface{}{
+			"title": title,
+			"text":  text + d.mentions(),
+		},
+	})
+}
+
+// mentions markdown 類訊息需在內文寫出 @ 對象才會真正提醒
+func (d *dingtalk) mentions() string {
+	var at []string
+	for _, mobile := range d.AtMobiles {
+		at = append(at, "@"+mobile)
+	}
+	for _, userID := range d.AtUserIDs {
+		at = append(at, "@"+userID)
+	}
+	if len(at) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(at, " ")
+}
+
+func (d *dingtalk) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["at"]; !ok {
+		message["at"] = map[string]interface{}{
+			"atMobiles": d.AtMobiles,
+			"atUserIds": d.AtUserIDs,
+			"isAtAll":   d.AtAll,
+		}
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("access_token", d.AccessToken)
+	if d.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(d.Secret))
+		mac.Write([]byte(timestamp + "\n" + d.Secret))
+		query.Set("timestamp", timestamp)
+		query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	req, err := http.NewRequest("POST", "https://oapi.dingtalk.com/robot/send?"+query.Encode(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("dingtalk responded with errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+
+	return nil
+}
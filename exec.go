@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ExecOption func(*execRunner)
+
+// ExecName 通知標題使用的名稱，預設為指令檔名
+func ExecName(name string) ExecOption {
+	return func(r *execRunner) { r.Name = name }
+}
+
+// ExecTailLines 通知內附上最後幾行輸出，預設 20 行；輸出更長時完整內容以附件送出
+func ExecTailLines(lines int) ExecOption {
+	return func(r *execRunner) { r.TailLines = lines }
+}
+
+// ExecFailureOnly 只在失敗時通知
+func ExecFailureOnly() ExecOption {
+	return func(r *execRunner) { r.FailureOnly = true }
+}
+
+type execRunner struct {
+	Name        string
+	TailLines   int
+	FailureOnly bool
+}
+
+// Exec 執行外部指令並通知結束碼、執行時間與最後幾行輸出 (stdout 與 stderr 合併)，回傳指令的錯誤：
+//
+//	n.Exec(ctx, "pg_dump", "-Fc", "-f", "/backup/game.dump", "game")
+func (n *Notify) Exec(ctx context.Context, cmd ...string) error {
+	if len(cmd) == 0 {
+		return errors.New("no command specified")
+	}
+	return n.ExecCommand(exec.CommandContext(ctx, cmd[0], cmd[1:]...))
+}
+
+// ExecCommand 同 Exec，可自行設定 Env、Dir 等；已設定的 Stdout/Stderr 仍會收到輸出
+func (n *Notify) ExecCommand(cmd *exec.Cmd, opts ...ExecOption) error {
+	r := &execRunner{
+		Name:      filepath.Base(cmd.Path),
+		TailLines: 20,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	output := &outputBuffer{max: 1 << 20}
+	if cmd.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, output)
+	} else {
+		cmd.Stdout = output
+	}
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, output)
+	} else {
+		cmd.Stderr = output
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start).Round(time.Millisecond)
+
+	exitCode := 0
+	if err != nil {
+		exitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+	if err == nil && r.FailureOnly {
+		return nil
+	}
+
+	message := Message{
+		Title:    "Command succeeded: " + r.Name,
+		Severity: SeverityInfo,
+		Fields: []Field{
+			{Name: "command", Value: strings.Join(cmd.Args, " ")},
+			{Name: "exit code", Value: strconv.Itoa(exitCode)},
+			{Name: "duration", Value: duration.String()},
+		},
+	}
+	if err != nil {
+		message.Title = "Command failed: " + r.Name
+		message.Severity = SeverityError
+		message.Key = "exec/" + r.Name
+	}
+
+	full := output.Bytes()
+	tail, truncated := tailLines(full, r.TailLines)
+	var text []string
+	if err != nil {
+		text = append(text, err.Error())
+	}
+	if tail != "" {
+		text = append(text, tail)
+	}
+	message.Text = strings.Join(text, "\n\n")
+	if truncated || output.dropped {
+		message.Attachments = []Attachment{{Name: r.Name + ".log", Data: full}}
+	}
+
+	n.Send(message)
+	return err
+}
+
+// tailLines 回傳最後 lines 行，以及是否有被截掉的內容
+func tailLines(data []byte, lines int) (string, bool) {
+	data = bytes.TrimRight(data, "\n")
+	if lines <= 0 {
+		return "", len(data) > 0
+	}
+	end := len(data)
+	for i := 0; i < lines; i++ {
+		end = bytes.LastIndexByte(data[:end], '\n')
+		if end < 0 {
+			return string(data), false
+		}
+	}
+	return string(data[end+1:]), true
+}
+
+// outputBuffer 保留指令輸出，超過上限時只保留最後的部分
+type outputBuffer struct {
+	max int
+
+	mu      sync.Mutex
+	buf     []byte
+	dropped bool
+}
+
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = append([]byte(nil), b.buf[len(b.buf)-b.max:]...)
+		b.dropped = true
+	}
+	return len(p), nil
+}
+
+func (b *outputBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf
+}
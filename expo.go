@@ -0,0 +1,234 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type ExpoOption func(*expo)
+
+// ExpoAccessToken 專案啟用 enhanced security 時需要
+func ExpoAccessToken(token string) ExpoOption {
+	return func(e *expo) { e.AccessToken = token }
+}
+
+// ExpoReceiptHandler 推送約 15 分鐘後才有回執，之後每次發送時會一併查詢先前的回執，
+// 失敗的 token（例如 DeviceNotRegistered）會交給 handler 處理；
+// 發送量少或程式結束前請另外定期呼叫 CheckExpoReceipts，避免最後一批回執沒有被查詢
+func ExpoReceiptHandler(handler func(pushToken string, err error)) ExpoOption {
+	return func(e *expo) { e.ReceiptHandler = handler }
+}
+
+func (n *Notify) Expo(pushTokens []string, opts ...ExpoOption) *Notify {
+	e := &expo{
+		PushTokens: pushTokens,
+		pending:    map[string]expoTicket{},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	n.add(e)
+	return n
+}
+
+type expo struct {
+	PushTokens     []string
+	AccessToken    string
+	ReceiptHandler func(pushToken string, err error)
+
+	mu      sync.Mutex
+	pending map[string]expoTicket
+}
+
+type expoTicket struct {
+	PushToken string
+	SentAt    time.Time
+}
+
+type expoResult struct {
+	Status  string `json:"status"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Details struct {
+		Error string `json:"error"`
+	} `json:"details"`
+}
+
+func (e *expo) Send(client *http.Client, message string) error {
+	return e.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+func (e *expo) SendMessage(client *http.Client, message Message) error {
+	push := map[string]interface{}{
+		"title": message.Title,
+		"body":  message.Text,
+		"sound": "default",
+	}
+	if message.Severity >= SeverityError {
+		push["priority"] = "high"
+	}
+
+	data := map[string]string{}
+	for _, field := range message.Fields {
+		data[field.Name] = field.Value
+	}
+	if message.URL != "" {
+		data["url"] = message.URL
+	}
+	if len(data) > 0 {
+		push["data"] = data
+	}
+
+	return e.SendRaw(client, push)
+}
+
+func (e *expo) SendRaw(client *http.Client, message map[string]interface{}) error {
+	e.checkReceipts(client, 15*time.Minute)
+
+	var tokens []string
+	if to, ok := message["to"].(string); ok {
+		tokens = []string{to}
+	} else {
+		tokens = e.PushTokens
+	}
+
+	var errs []error
+	// 每次請求最多 100 則
+	for start := 0; start < len(tokens); start += 100 {
+		end := min(start+100, len(tokens))
+
+		var pushes []map[string]interface{}
+		for _, token := range tokens[start:end] {
+			pushes = append(pushes, withField(message, "to", token))
+		}
+
+		var result struct {
+			Data []expoResult `json:"data"`
+		}
+		if err := e.post(client, "https://exp.host/--/api/v2/push/send", pushes, &result); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// handler 在釋放鎖之後才呼叫，handler 內再次發送也不會卡住
+		failed := map[string]error{}
+		e.mu.Lock()
+		for i, ticket := range result.Data {
+			if i >= end-start {
+				break
+			}
+			token := tokens[start+i]
+			if ticket.Status != "ok" {
+				errs = append(errs, fmt.Errorf("expo %s: %s", token, ticket.Message))
+				failed[token] = errors.New(ticket.Details.Error)
+				continue
+			}
+			if e.ReceiptHandler != nil {
+				e.pending[ticket.ID] = expoTicket{PushToken: token, SentAt: time.Now()}
+			}
+		}
+		e.mu.Unlock()
+
+		if e.ReceiptHandler != nil {
+			for token, err := range failed {
+				e.ReceiptHandler(token, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckExpoReceipts 立即查詢所有 Expo 通道尚未取得的回執，不論送出多久；
+// 可用 ticker 定期呼叫，或在程式結束前呼叫一次
+func (n *Notify) CheckExpoReceipts() error {
+	var errs []error
+	for _, notifier := range n.notifiers() {
+		if e, ok := notifier.(*expo); ok {
+			if err := e.checkReceipts(n.Client, 0); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkReceipts 查詢送出超過 minAge 的回執，失敗的 token 在釋放鎖之後交給 handler
+func (e *expo) checkReceipts(client *http.Client, minAge time.Duration) error {
+	if e.ReceiptHandler == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	var ids []string
+	for id, ticket := range e.pending {
+		if time.Since(ticket.SentAt) >= minAge && len(ids) < 1000 {
+			ids = append(ids, id)
+		}
+	}
+	e.mu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var result struct {
+		Data map[string]expoResult `json:"data"`
+	}
+	if err := e.post(client, "https://exp.host/--/api/v2/push/getReceipts", map[string]interface{}{"ids": ids}, &result); err != nil {
+		return err
+	}
+
+	type failure struct {
+		token string
+		err   error
+	}
+	var failures []failure
+	e.mu.Lock()
+	for _, id := range ids {
+		ticket := e.pending[id]
+		receipt, ok := result.Data[id]
+		if !ok && time.Since(ticket.SentAt) < 24*time.Hour {
+			// 回執尚未產生，下次再查
+			continue
+		}
+		delete(e.pending, id)
+		if ok && receipt.Status != "ok" {
+			failures = append(failures, failure{ticket.PushToken, fmt.Errorf("%s: %s", receipt.Details.Error, receipt.Message)})
+		}
+	}
+	e.mu.Unlock()
+
+	for _, f := range failures {
+		e.ReceiptHandler(f.token, f.err)
+	}
+	return nil
+}
+
+func (e *expo) post(client *http.Client, url string, body interface{}, result interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if e.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+e.AccessToken)
+	}
+
+	return requestJSON(client, req, result)
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExpoReceiptHandlerOutsideLock(t *testing.T) {
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/--/api/v2/push/send":
+			w.Write([]byte(`{"data":[{"status":"ok","id":"ok-ticket"},{"status":"error","message":"gone","details":{"error":"DeviceNotRegistered"}}]}`))
+		case "/--/api/v2/push/getReceipts":
+			w.Write([]byte(`{"data":{"ok-ticket":{"status":"error","message":"expired","details":{"error":"DeviceNotRegistered"}}}}`))
+		}
+	}))
+	defer done()
+
+	var mu sync.Mutex
+	var failed []string
+	n.Expo([]string{"ExponentPushToken[a]", "ExponentPushToken[b]"}, ExpoReceiptHandler(func(token string, err error) {
+		// handler 內再次發送不應卡住
+		n.Notifiers[0].(*expo).mu.Lock()
+		n.Notifiers[0].(*expo).mu.Unlock()
+		mu.Lock()
+		failed = append(failed, token+" "+err.Error())
+		mu.Unlock()
+	}))
+
+	finished := make(chan error, 1)
+	go func() { finished <- n.Send("hello") }()
+	select {
+	case err := <-finished:
+		if err == nil || !strings.Contains(err.Error(), "gone") {
+			t.Fatalf("Send error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send deadlocked in receipt handler")
+	}
+
+	if err := n.CheckExpoReceipts(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(failed) != 2 || !strings.HasPrefix(failed[0], "ExponentPushToken[b]") || !strings.HasPrefix(failed[1], "ExponentPushToken[a] DeviceNotRegistered") {
+		t.Fatalf("handler got %q", failed)
+	}
+}
@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type FCMOption func(*fcm)
+
+func FCMTopic(topic string) FCMOption {
+	return func(f *fcm) { f.Topic = topic }
+}
+
+// FCMTokens 指定裝置 registration token，HTTP v1 每個 token 各送一次
+func FCMTokens(tokens ...string) FCMOption {
+	return func(f *fcm) { f.Tokens = append(f.Tokens, tokens...) }
+}
+
+// FCMCondition 例如 "'game' in topics && 'tw' in topics"
+func FCMCondition(condition string) FCMOption {
+	return func(f *fcm) { f.Condition = condition }
+}
+
+// FCMData 附加 data payload，供 App 端自行處理
+func FCMData(data map[string]string) FCMOption {
+	return func(f *fcm) { f.Data = data }
+}
+
+func (n *Notify) FCM(serviceAccountJSON []byte, opts ...FCMOption) *Notify {
+	f := &fcm{}
+	f.Credentials, f.err = newGoogleCredentials(serviceAccountJSON, "https://www.googleapis.com/auth/firebase.messaging")
+	for _, opt := range opts {
+		opt(f)
+	}
+	n.add(f)
+	return n
+}
+
+type fcm struct {
+	Credentials *googleCredentials
+	Topic       string
+	Tokens      []string
+	Condition   string
+	Data        map[string]string
+
+	err error
+}
+
+func (f *fcm) Send(client *http.Client, message string) error {
+	return f.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+func (f *fcm) SendMessage(client *http.Client, message Message) error {
+	data := map[string]string{}
+	for key, value := range f.Data {
+		data[key] = value
+	}
+	for _, field := range message.Fields {
+		data[field.Name] = field.Value
+	}
+	data["severity"] = message.Severity.String()
+	if message.Key != "" {
+		data["key"] = message.Key
+	}
+	if message.URL != "" {
+		data["url"] = message.URL
+	}
+
+	return f.SendRaw(client, map[string]interface{}{
+		"notification": map[string]interface{}{
+			"title": message.Title,
+			"body":  message.Text,
+		},
+		"data": data,
+	})
+}
+
+// SendRaw 傳入 HTTP v1 的 message 物件，未指定 token/topic/condition 時使用設定的對象
+func (f *fcm) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	_, hasToken := message["token"]
+	_, hasTopic := message["topic"]
+	_, hasCondition := message["condition"]
+	if hasToken || hasTopic || hasCondition {
+		return f.post(client, message)
+	}
+	if f.Topic == "" && f.Condition == "" && len(f.Tokens) == 0 {
+		return errors.New("fcm: no topic, condition or tokens configured")
+	}
+
+	var errs []error
+	if f.Topic != "" {
+		errs = append(errs, f.post(client, withField(message, "topic", f.Topic)))
+	}
+	if f.Condition != "" {
+		errs = append(errs, f.post(client, withField(message, "condition", f.Condition)))
+	}
+	for _, token := range f.Tokens {
+		errs = append(errs, f.post(client, withField(message, "token", token)))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *fcm) post(client *http.Client, message map[string]interface{}) error {
+	token, err := f.Credentials.token(client)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{"message": message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://fcm.googleapis.com/v1/projects/%s/messages:send", f.Credentials.ProjectID)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return request(client, req)
+}
+
+// withField 複製一份 map 並設定欄位，避免多個對象共用同一份 payload
+func withField(message map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(message)+1)
+	for k, v := range message {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
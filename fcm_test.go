@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testServiceAccount(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, _ := json.Marshal(map[string]string{
+		"project_id":   "demo",
+		"client_email": "notify@demo.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+	return account
+}
+
+func TestFCMWithoutTargetFails(t *testing.T) {
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer done()
+
+	n.FCM(testServiceAccount(t))
+	err := n.Send("hello")
+	if err == nil || !strings.Contains(err.Error(), "no topic, condition or tokens") {
+		t.Fatalf("Send error = %v", err)
+	}
+}
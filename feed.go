@@ -0,0 +1,367 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type FeedOption func(*FeedWatcher)
+
+// FeedInterval 輪詢間隔，預設 5 分鐘
+func FeedInterval(interval time.Duration) FeedOption {
+	return func(w *FeedWatcher) { w.Interval = interval }
+}
+
+// FeedStore 已通知項目保存的 JSON 檔，重啟後不會重送；未指定時只保存在記憶體
+func FeedStore(path string) FeedOption {
+	return func(w *FeedWatcher) { w.StorePath = path }
+}
+
+// FeedSeverity 新項目的嚴重度，預設 Info
+func FeedSeverity(severity Severity) FeedOption {
+	return func(w *FeedWatcher) { w.Severity = severity }
+}
+
+// FeedFilter 只通知標題符合 pattern (regexp) 的項目，例如 "(?i)outage|degraded"
+func FeedFilter(pattern string) FeedOption {
+	return func(w *FeedWatcher) {
+		w.Filter, w.err = regexp.Compile(pattern)
+		if w.err != nil {
+			w.err = fmt.Errorf("invalid feed filter %q: %v", pattern, w.err)
+		}
+	}
+}
+
+// Feed 輪詢 RSS/Atom feed (例如狀態頁、release feed)，有新項目時通知標題、連結與摘要；
+// 第一次讀取的 feed 只記錄既有項目，不會全部送出
+func (n *Notify) Feed(urls []string, opts ...FeedOption) *FeedWatcher {
+	w := &FeedWatcher{
+		URLs:     urls,
+		Interval: 5 * time.Minute,
+		Severity: SeverityInfo,
+		notify:   n,
+		state:    map[string]*feedState{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+type FeedWatcher struct {
+	URLs      []string
+	Interval  time.Duration
+	StorePath string
+	Severity  Severity
+	Filter    *regexp.Regexp
+
+	notify *Notify
+	mu     sync.Mutex
+	state  map[string]*feedState
+	loaded bool
+	err    error
+}
+
+type feedState struct {
+	Seen         []string `json:"seen"`
+	ETag         string   `json:"etag,omitempty"`
+	LastModified string   `json:"last_modified,omitempty"`
+}
+
+// feedDocument 同時對應 RSS 2.0 (channel/item)、RSS 1.0 (item 在根節點) 與 Atom (entry)
+type feedDocument struct {
+	XMLName xml.Name
+	Title   string `xml:"title"`
+	Channel struct {
+		Title string     `xml:"title"`
+		Items []feedItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []feedItem `xml:"item"`
+	Entries []struct {
+		Title string `xml:"title"`
+		ID    string `xml:"id"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Updated   string `xml:"updated"`
+		Published string `xml:"published"`
+	} `xml:"entry"`
+}
+
+type feedItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"date"`
+}
+
+type feedEntry struct {
+	ID        string
+	Title     string
+	Link      string
+	Summary   string
+	Published string
+}
+
+// Run 依間隔輪詢直到 ctx 結束，FeedFilter 無效時立即回傳錯誤
+func (w *FeedWatcher) Run(ctx context.Context) error {
+	if w.err != nil {
+		return w.err
+	}
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Check(); err != nil {
+			log.Println("notify feed error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 輪詢所有 feed 一次，回傳各 feed 的錯誤；FeedFilter 無效時直接回傳錯誤
+func (w *FeedWatcher) Check() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.loaded {
+		if err := w.load(); err != nil {
+			return err
+		}
+		w.loaded = true
+	}
+
+	var errs []string
+	for _, url := range w.URLs {
+		if err := w.check(url); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", url, err))
+		}
+	}
+
+	if err := w.save(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (w *FeedWatcher) check(url string) error {
+	// 新 feed 的狀態在第一次成功解析後才記錄，避免讀取失敗後把整份 feed 當成新項目送出
+	state, known := w.state[url]
+	if !known {
+		state = &feedState{}
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+	if state.ETag != "" {
+		req.Header.Set("If-None-Match", state.ETag)
+	}
+	if state.LastModified != "" {
+		req.Header.Set("If-Modified-Since", state.LastModified)
+	}
+
+	resp, err := w.notify.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("feed responded with status: %v", resp.Status)
+	}
+
+	var doc feedDocument
+	decoder := xml.NewDecoder(resp.Body)
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("failed to parse feed: %v", err)
+	}
+	state.ETag = resp.Header.Get("ETag")
+	state.LastModified = resp.Header.Get("Last-Modified")
+	w.state[url] = state
+
+	feedTitle, entries := doc.entries()
+	seen := make(map[string]bool, len(state.Seen))
+	for _, id := range state.Seen {
+		seen[id] = true
+	}
+
+	// feed 通常由新到舊排列，反向送出讓通知依時間順序
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if seen[entry.ID] {
+			continue
+		}
+		seen[entry.ID] = true
+		state.Seen = append(state.Seen, entry.ID)
+
+		if !known || (w.Filter != nil && !w.Filter.MatchString(entry.Title)) {
+			continue
+		}
+
+		fields := []Field{{Name: "feed", Value: feedTitle}}
+		if entry.Published != "" {
+			fields = append(fields, Field{Name: "published", Value: entry.Published})
+		}
+		w.notify.Send(Message{
+			Title:    entry.Title,
+			Text:     entry.Summary,
+			Severity: w.Severity,
+			Fields:   fields,
+			URL:      entry.Link,
+			Key:      "feed/" + entry.ID,
+		})
+	}
+
+	// 只保留最近的項目，避免檔案無限成長
+	if len(state.Seen) > 500 {
+		state.Seen = state.Seen[len(state.Seen)-500:]
+	}
+	return nil
+}
+
+func (d feedDocument) entries() (string, []feedEntry) {
+	title := d.Channel.Title
+	if title == "" {
+		title = d.Title
+	}
+
+	var entries []feedEntry
+	for _, item := range append(d.Channel.Items, d.Items...) {
+		id := item.GUID
+		if id == "" {
+			id = item.Link
+		}
+		if id == "" {
+			id = item.Title
+		}
+		published := item.PubDate
+		if published == "" {
+			published = item.Date
+		}
+		entries = append(entries, feedEntry{
+			ID:        id,
+			Title:     strings.TrimSpace(item.Title),
+			Link:      strings.TrimSpace(item.Link),
+			Summary:   feedSummary(item.Description),
+			Published: published,
+		})
+	}
+
+	for _, entry := range d.Entries {
+		var link string
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		summary := entry.Summary
+		if summary == "" {
+			summary = entry.Content
+		}
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+		id := entry.ID
+		if id == "" {
+			id = link
+		}
+		entries = append(entries, feedEntry{
+			ID:        id,
+			Title:     strings.TrimSpace(entry.Title),
+			Link:      link,
+			Summary:   feedSummary(summary),
+			Published: published,
+		})
+	}
+	return strings.TrimSpace(title), entries
+}
+
+// feedSummary 去除 HTML 標籤並截斷為 500 字
+func feedSummary(s string) string {
+	s = html.UnescapeString(htmlTag.ReplaceAllString(s, " "))
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 500 {
+		s = string(r[:500]) + "…"
+	}
+	return s
+}
+
+func (w *FeedWatcher) load() error {
+	if w.StorePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(w.StorePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &w.state); err != nil {
+		return fmt.Errorf("invalid feed store %s: %v", w.StorePath, err)
+	}
+	return nil
+}
+
+// save 先寫入暫存檔再 rename，避免中斷時留下不完整的檔案
+func (w *FeedWatcher) save() error {
+	if w.StorePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(w.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(w.StorePath), ".feed-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), w.StorePath)
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFeedFailedFirstPollDoesNotFlood(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	var items atomic.Value
+	items.Store(`<item><title>one</title><guid>1</guid></item><item><title>two</title><guid>2</guid></item>`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`<rss><channel><title>status</title>` + items.Load().(string) + `</channel></rss>`))
+	}))
+	defer srv.Close()
+
+	rec := &recordNotifier{}
+	n := New()
+	n.Notifiers = append(n.Notifiers, rec)
+	w := n.Feed([]string{srv.URL})
+
+	if err := w.Check(); err == nil {
+		t.Fatal("expected error from failing feed")
+	}
+	fail.Store(false)
+	if err := w.Check(); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.messages(); len(got) != 0 {
+		t.Fatalf("baseline poll sent %q", got)
+	}
+
+	items.Store(`<item><title>three</title><guid>3</guid></item>` + items.Load().(string))
+	if err := w.Check(); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.messages(); len(got) != 1 {
+		t.Fatalf("sent %q, want only the new item", got)
+	}
+}
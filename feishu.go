@@ -0,0 +1,251 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type FeishuOption func(*feishu)
+
+// FeishuSecret 自訂機器人啟用「簽名校驗」時的密鑰
+func FeishuSecret(secret string) FeishuOption {
+	return func(f *feishu) { f.Secret = secret }
+}
+
+// FeishuLark 應用訊息改用國際版 Lark 網域
+func FeishuLark() FeishuOption {
+	return func(f *feishu) { f.BaseURL = "https://open.larksuite.com" }
+}
+
+// Feishu 自訂機器人 webhook，Lark 直接傳入 larksuite 的 webhook 網址即可
+func (n *Notify) Feishu(webhookURL string, opts ...FeishuOption) *Notify {
+	f := &feishu{
+		WebhookURL: webhookURL,
+		BaseURL:    "https://open.feishu.cn",
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	n.add(f)
+	return n
+}
+
+// FeishuApp 以應用身分發訊息，receiveIDType 為 chat_id、open_id、user_id、union_id 或 email
+func (n *Notify) FeishuApp(appID, appSecret, receiveIDType, receiveID string, opts ...FeishuOption) *Notify {
+	f := &feishu{
+		AppID:         appID,
+		AppSecret:     appSecret,
+		ReceiveIDType: receiveIDType,
+		ReceiveID:     receiveID,
+		BaseURL:       "https://open.feishu.cn",
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	n.add(f)
+	return n
+}
+
+type feishu struct {
+	WebhookURL    string
+	Secret        string
+	AppID         string
+	AppSecret     string
+	ReceiveIDType string
+	ReceiveID     string
+	BaseURL       string
+
+	mu          sync.Mutex
+	tenantToken string
+	expiresAt   time.Time
+}
+
+func (f *feishu) Send(client *http.Client, message string) error {
+	return f.SendRaw(client, map[string]interface{}{
+		"msg_type": "text",
+		"content":  map[string]interface{}{"text": message},
+	})
+}
+
+func (f *feishu) SendMessage(client *http.Client, message Message) error {
+	return f.SendRaw(client, map[string]interface{}{
+		"msg_type": "interactive",
+		"card":     feishuCard(message),
+	})
+}
+
+// feishuCard header 顏色與 severityColor 相同規則：resolved 為綠色，其餘依 Severity
+func feishuCard(message Message) map[string]interface{} {
+	template := "blue"
+	switch {
+	case message.Status == StatusResolved:
+		template = "green"
+	case message.Severity == SeverityWarning:
+		template = "orange"
+	case message.Severity >= SeverityError:
+		template = "red"
+	}
+
+	var elements []map[string]interface{}
+	if message.Text != "" {
+		elements = append(elements, map[string]interface{}{
+			"tag":  "div",
+			"text": map[string]interface{}{"tag": "lark_md", "content": message.Text},
+		})
+	}
+	if len(message.Fields) > 0 {
+		var fields []map[string]interface{}
+		for _, field := range message.Fields {
+			fields = append(fields, map[string]interface{}{
+				"is_short": true,
+				"text": map[string]interface{}{
+					"tag":     "lark_md",
+					"content": fmt.Sprintf("**%s**\n%s", field.Name, field.Value),
+				},
+			})
+		}
+		elements = append(elements, map[string]interface{}{"tag": "div", "fields": fields})
+	}
+	if message.URL != "" {
+		elements = append(elements, map[string]interface{}{
+			"tag": "action",
+			"actions": []map[string]interface{}{{
+				"tag":  "button",
+				"text": map[string]interface{}{"tag": "plain_text", "content": "查看詳情"},
+				"url":  message.URL,
+				"type": "primary",
+			}},
+		})
+	}
+
+	title := message.heading()
+	if title == "" {
+		title = subjectOf(message.Text)
+	}
+	return map[string]interface{}{
+		"config": map[string]interface{}{"wide_screen_mode": true},
+		"header": map[string]interface{}{
+			"template": template,
+			"title":    map[string]interface{}{"tag": "plain_text", "content": title},
+		},
+		"elements": elements,
+	}
+}
+
+func (f *feishu) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if f.WebhookURL != "" {
+		if f.Secret != "" {
+			// 簽名以 timestamp + "\n" + secret 為金鑰對空字串做 HmacSHA256
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			mac := hmac.New(sha256.New, []byte(timestamp+"\n"+f.Secret))
+			message["timestamp"] = timestamp
+			message["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		}
+		return f.post(client, f.WebhookURL, "", message)
+	}
+
+	token, err := f.token(client)
+	if err != nil {
+		return err
+	}
+
+	// 應用訊息 API 的 content 需為 JSON 字串，卡片則放在 content 內
+	msgType, _ := message["msg_type"].(string)
+	content := message["content"]
+	if card, ok := message["card"]; ok {
+		content = card
+	}
+	if _, ok := content.(string); !ok {
+		contentData, err := json.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		content = string(contentData)
+	}
+
+	url := fmt.Sprintf("%s/open-apis/im/v1/messages?receive_id_type=%s", f.BaseURL, url.QueryEscape(f.ReceiveIDType))
+	return f.post(client, url, token, map[string]interface{}{
+		"receive_id": f.ReceiveID,
+		"msg_type":   msgType,
+		"content":    content,
+	})
+}
+
+func (f *feishu) token(client *http.Client) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.tenantToken != "" && time.Now().Before(f.expiresAt) {
+		return f.tenantToken, nil
+	}
+
+	jsonData, err := json.Marshal(map[string]string{
+		"app_id":     f.AppID,
+		"app_secret": f.AppSecret,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", f.BaseURL+"/open-apis/auth/v3/tenant_access_token/internal", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	var result struct {
+		Code              int    `json:"code"`
+		Msg               string `json:"msg"`
+		TenantAccessToken string `json:"tenant_access_token"`
+		Expire            int    `json:"expire"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+	if result.Code != 0 {
+		return "", fmt.Errorf("feishu tenant_access_token responded with code %d: %s", result.Code, result.Msg)
+	}
+
+	f.tenantToken = result.TenantAccessToken
+	f.expiresAt = time.Now().Add(time.Duration(result.Expire)*time.Second - 5*time.Minute)
+	return f.tenantToken, nil
+}
+
+func (f *feishu) post(client *http.Client, url, token string, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("feishu responded with code %d: %s", result.Code, strings.TrimSpace(result.Msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// File 將每則訊息以 JSON line 附加到檔案，可作為本機稽核紀錄或備援通道
+func (n *Notify) File(path string) *Notify {
+	n.add(&file{Path: path, notify: n})
+	return n
+}
+
+// Output 與 File 相同，但寫入任意 io.Writer，例如 os.Stdout
+func (n *Notify) Output(w io.Writer) *Notify {
+	n.add(&file{Writer: w, notify: n})
+	return n
+}
+
+type file struct {
+	Path   string
+	Writer io.Writer
+
+	mu     sync.Mutex
+	notify *Notify
+}
+
+type fileRecord struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Severity  string                 `json:"severity,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+	Title     string                 `json:"title,omitempty"`
+	Text      string                 `json:"text,omitempty"`
+	Fields    map[string]string      `json:"fields,omitempty"`
+	URL       string                 `json:"url,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	Raw       map[string]interface{} `json:"raw,omitempty"`
+	Targets   []string               `json:"targets,omitempty"`
+}
+
+func (f *file) Send(_ *http.Client, message string) error {
+	return f.write(fileRecord{Text: message})
+}
+
+func (f *file) SendMessage(_ *http.Client, message Message) error {
+	return f.write(messageRecord(message))
+}
+
+func (f *file) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	return f.write(fileRecord{Raw: message})
+}
+
+// messageRecord 結構化訊息轉為 JSON 紀錄，供檔案及訊息佇列類 notifier 共用
+func messageRecord(message Message) fileRecord {
+	record := fileRecord{
+		Severity: message.Severity.String(),
+		Status:   message.Status.String(),
+		Title:    message.Title,
+		Text:     message.Text,
+		URL:      message.URL,
+		Key:      message.Key,
+	}
+	if len(message.Fields) > 0 {
+		record.Fields = make(map[string]string, len(message.Fields))
+		for _, field := range message.Fields {
+			record.Fields[field.Name] = field.Value
+		}
+	}
+	return record
+}
+
+func (f *file) write(record fileRecord) error {
+	record.Timestamp = time.Now().UTC()
+	record.Targets = f.targets()
+
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	jsonData = append(jsonData, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Writer != nil {
+		_, err = f.Writer.Write(jsonData)
+		return err
+	}
+
+	// 每次重新開檔，方便 logrotate 搬移檔案
+	fp, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %v", err)
+	}
+	if _, err := fp.Write(jsonData); err != nil {
+		fp.Close()
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	return fp.Close()
+}
+
+// targets 同一個 Notify 中其他通道的名稱
+func (f *file) targets() []string {
+	targets := []string{}
+	for _, notifier := range f.notify.notifiers() {
+		if notifier == INotify(f) {
+			continue
+		}
+		targets = append(targets, notifierName(notifier))
+	}
+	return targets
+}
+
+func notifierName(notifier INotify) string {
+	t := reflect.TypeOf(notifier)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
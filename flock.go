@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+func (n *Notify) Flock(webhookURL string) *Notify {
+	n.add(&flock{
+		WebhookURL: webhookURL,
+	})
+	return n
+}
+
+type flock struct {
+	WebhookURL string
+}
+
+func (f *flock) Send(client *http.Client, message string) error {
+	return f.SendRaw(client, map[string]interface{}{"text": message})
+}
+
+func (f *flock) SendMessage(client *http.Client, message Message) error {
+	var body []string
+	if message.Text != "" {
+		body = append(body, html.EscapeString(message.Text))
+	}
+	for _, field := range message.Fields {
+		body = append(body, fmt.Sprintf("<b>%s</b>: %s", html.EscapeString(field.Name), html.EscapeString(field.Value)))
+	}
+	flockml := "<flockml>" + strings.ReplaceAll(strings.Join(body, "\n"), "\n", "<br/>") + "</flockml>"
+
+	attachment := map[string]interface{}{
+		"title": fmt.Sprintf("[%s] %s", message.Severity, message.Title),
+		"color": severityColor(message),
+		"views": map[string]interface{}{"flockml": flockml},
+	}
+	if message.URL != "" {
+		attachment["url"] = message.URL
+	}
+
+	return f.SendRaw(client, map[string]interface{}{
+		"text":        message.String(),
+		"attachments": []map[string]interface{}{attachment},
+	})
+}
+
+func (f *flock) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", f.WebhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
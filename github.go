@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type GitHubOption func(*github)
+
+func GitHubLabels(labels ...string) GitHubOption {
+	return func(g *github) { g.Labels = append(g.Labels, labels...) }
+}
+
+// GitHubCommentOn 一律留言在指定的 issue 或 PR，不建立新 issue
+func GitHubCommentOn(number int) GitHubOption {
+	return func(g *github) { g.Number = number }
+}
+
+// GitHubAPIURL GitHub Enterprise Server 使用，例如 https://github.example.com/api/v3
+func GitHubAPIURL(apiURL string) GitHubOption {
+	return func(g *github) { g.APIURL = strings.TrimSuffix(apiURL, "/") }
+}
+
+// GitHub repo 格式為 owner/name；Message.Key 有值時會先尋找同一 key 仍開啟的 issue 並改為留言
+func (n *Notify) GitHub(token, repo string, opts ...GitHubOption) *Notify {
+	g := &github{
+		Token:  token,
+		Repo:   repo,
+		APIURL: "https://api.github.com",
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	n.add(g)
+	return n
+}
+
+type github struct {
+	Token  string
+	Repo   string
+	APIURL string
+	Labels []string
+	Number int
+}
+
+func (g *github) Send(client *http.Client, message string) error {
+	if g.Number != 0 {
+		return g.comment(client, g.Number, message)
+	}
+	return g.create(client, subjectOf(message), message, nil)
+}
+
+func (g *github) SendMessage(client *http.Client, message Message) error {
+	body := message.String()
+
+	if g.Number != 0 {
+		return g.comment(client, g.Number, body)
+	}
+	if message.Key == "" {
+		return g.create(client, message.Title, body, nil)
+	}
+
+	label := "notify-" + sha256Hex([]byte(message.Key))[:12]
+	number, err := g.find(client, label)
+	if err != nil {
+		return err
+	}
+	if number != 0 {
+		return g.comment(client, number, body)
+	}
+	if message.Status == StatusResolved {
+		return nil
+	}
+	return g.create(client, message.Title, body, []string{label})
+}
+
+// SendRaw 傳入 create issue 的 payload，例如 {"title", "body", "labels", "assignees"}
+func (g *github) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["labels"]; !ok && len(g.Labels) > 0 {
+		message["labels"] = g.Labels
+	}
+	return g.post(client, fmt.Sprintf("/repos/%s/issues", g.Repo), message)
+}
+
+func (g *github) create(client *http.Client, title, body string, labels []string) error {
+	if title == "" {
+		title = subjectOf(body)
+	}
+	issue := map[string]interface{}{
+		"title": title,
+		"body":  body,
+	}
+	if labels = append(append([]string{}, g.Labels...), labels...); len(labels) > 0 {
+		issue["labels"] = labels
+	}
+	return g.SendRaw(client, issue)
+}
+
+func (g *github) comment(client *http.Client, number int, body string) error {
+	return g.post(client, fmt.Sprintf("/repos/%s/issues/%d/comments", g.Repo, number), map[string]interface{}{
+		"body": body,
+	})
+}
+
+// find 以 label 找出仍開啟的 issue，找不到時回傳 0
+func (g *github) find(client *http.Client, label string) (int, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "open")
+	query.Set("per_page", "1")
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/issues?%s", g.APIURL, g.Repo, query.Encode()), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	g.authorize(req)
+
+	var issues []struct {
+		Number int `json:"number"`
+	}
+	if err := requestJSON(client, req, &issues); err != nil {
+		return 0, err
+	}
+	if len(issues) == 0 {
+		return 0, nil
+	}
+	return issues[0].Number, nil
+}
+
+func (g *github) post(client *http.Client, path string, payload map[string]interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", g.APIURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	g.authorize(req)
+
+	return request(client, req)
+}
+
+func (g *github) authorize(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type GitLabOption func(*gitlab)
+
+func GitLabLabels(labels ...string) GitLabOption {
+	return func(g *gitlab) { g.Labels = append(g.Labels, labels...) }
+}
+
+// GitLabCommentOn 一律在指定 issue (iid) 新增 note，不建立新 issue
+func GitLabCommentOn(iid int) GitLabOption {
+	return func(g *gitlab) { g.IID = iid }
+}
+
+// GitLabBaseURL 自架 GitLab 使用，預設 https://gitlab.com
+func GitLabBaseURL(baseURL string) GitLabOption {
+	return func(g *gitlab) { g.BaseURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// GitLab project 為數字 ID 或 group/project 路徑；Message.Key 有值時會先尋找同一 key 仍開啟的 issue 並改為留言
+func (n *Notify) GitLab(token, project string, opts ...GitLabOption) *Notify {
+	g := &gitlab{
+		Token:   token,
+		Project: project,
+		BaseURL: "https://gitlab.com",
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	n.add(g)
+	return n
+}
+
+type gitlab struct {
+	Token   string
+	Project string
+	BaseURL string
+	Labels  []string
+	IID     int
+}
+
+func (g *gitlab) Send(client *http.Client, message string) error {
+	if g.IID != 0 {
+		return g.note(client, g.IID, message)
+	}
+	return g.create(client, subjectOf(message), message, nil)
+}
+
+func (g *gitlab) SendMessage(client *http.Client, message Message) error {
+	body := message.String()
+
+	if g.IID != 0 {
+		return g.note(client, g.IID, body)
+	}
+	if message.Key == "" {
+		return g.create(client, message.Title, body, nil)
+	}
+
+	label := "notify-" + sha256Hex([]byte(message.Key))[:12]
+	iid, err := g.find(client, label)
+	if err != nil {
+		return err
+	}
+	if iid != 0 {
+		return g.note(client, iid, body)
+	}
+	if message.Status == StatusResolved {
+		return nil
+	}
+	return g.create(client, message.Title, body, []string{label})
+}
+
+// SendRaw 傳入 create issue 的 payload，例如 {"title", "description", "labels", "confidential"}
+func (g *gitlab) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["labels"]; !ok && len(g.Labels) > 0 {
+		message["labels"] = strings.Join(g.Labels, ",")
+	}
+	return g.post(client, "/issues", message)
+}
+
+func (g *gitlab) create(client *http.Client, title, description string, labels []string) error {
+	if title == "" {
+		title = subjectOf(description)
+	}
+	issue := map[string]interface{}{
+		"title":       title,
+		"description": description,
+	}
+	if labels = append(append([]string{}, g.Labels...), labels...); len(labels) > 0 {
+		issue["labels"] = strings.Join(labels, ",")
+	}
+	return g.SendRaw(client, issue)
+}
+
+func (g *gitlab) note(client *http.Client, iid int, body string) error {
+	return g.post(client, fmt.Sprintf("/issues/%d/notes", iid), map[string]interface{}{
+		"body": body,
+	})
+}
+
+// find 以 label 找出仍開啟的 issue，找不到時回傳 0
+func (g *gitlab) find(client *http.Client, label string) (int, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "opened")
+	query.Set("per_page", "1")
+
+	req, err := http.NewRequest("GET", g.projectURL()+"/issues?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.Token)
+
+	var issues []struct {
+		IID int `json:"iid"`
+	}
+	if err := requestJSON(client, req, &issues); err != nil {
+		return 0, err
+	}
+	if len(issues) == 0 {
+		return 0, nil
+	}
+	return issues[0].IID, nil
+}
+
+func (g *gitlab) projectURL() string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", g.BaseURL, url.PathEscape(g.Project))
+}
+
+func (g *gitlab) post(client *http.Client, path string, payload map[string]interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", g.projectURL()+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", g.Token)
+
+	return request(client, req)
+}
@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// googleCredentials 以 service account 金鑰換取 OAuth2 access token 並快取
+type googleCredentials struct {
+	ProjectID   string
+	ClientEmail string
+	TokenURI    string
+	Scope       string
+	Key         crypto.Signer
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func newGoogleCredentials(serviceAccountJSON []byte, scope string) (*googleCredentials, error) {
+	var account struct {
+		ProjectID    string `json:"project_id"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(serviceAccountJSON, &account); err != nil {
+		return nil, fmt.Errorf("invalid service account json: %v", err)
+	}
+
+	key, err := parsePrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account private key: %v", err)
+	}
+
+	if account.TokenURI == "" {
+		account.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	return &googleCredentials{
+		ProjectID:   account.ProjectID,
+		ClientEmail: account.ClientEmail,
+		TokenURI:    account.TokenURI,
+		Scope:       scope,
+		Key:         key,
+	}, nil
+}
+
+func (g *googleCredentials) token(client *http.Client) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Now().Before(g.expiresAt) {
+		return g.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(map[string]interface{}{}, map[string]interface{}{
+		"iss":   g.ClientEmail,
+		"scope": g.Scope,
+		"aud":   g.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}, g.Key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequest("POST", g.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", fmt.Errorf("failed to fetch google access token: %v", err)
+	}
+
+	g.accessToken = result.AccessToken
+	g.expiresAt = now.Add(time.Duration(result.ExpiresIn)*time.Second - 5*time.Minute)
+	return g.accessToken, nil
+}
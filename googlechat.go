@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type GoogleChatOption func(*googlechat)
+
+// GoogleChatThreadKey 固定訊息串，Message.Key 有值時以 Key 為準
+func GoogleChatThreadKey(key string) GoogleChatOption {
+	return func(g *googlechat) { g.ThreadKey = key }
+}
+
+func (n *Notify) GoogleChat(webhookURL string, opts ...GoogleChatOption) *Notify {
+	g := &googlechat{WebhookURL: webhookURL}
+	for _, opt := range opts {
+		opt(g)
+	}
+	n.add(g)
+	return n
+}
+
+type googlechat struct {
+	WebhookURL string
+	ThreadKey  string
+}
+
+func (g *googlechat) Send(client *http.Client, message string) error {
+	return g.post(client, map[string]interface{}{"text": message}, g.ThreadKey)
+}
+
+func (g *googlechat) SendRaw(client *http.Client, message map[string]interface{}) error {
+	return g.post(client, message, g.ThreadKey)
+}
+
+func (g *googlechat) SendMessage(client *http.Client, message Message) error {
+	var widgets []map[string]interface{}
+	if message.Text != "" {
+		widgets = append(widgets, map[string]interface{}{
+			"textParagraph": map[string]interface{}{"text": message.Text},
+		})
+	}
+	for _, field := range message.Fields {
+		widgets = append(widgets, map[string]interface{}{
+			"decoratedText": map[string]interface{}{
+				"topLabel": field.Name,
+				"text":     field.Value,
+			},
+		})
+	}
+	if message.URL != "" {
+		widgets = append(widgets, map[string]interface{}{
+			"buttonList": map[string]interface{}{
+				"buttons": []map[string]interface{}{{
+					"text": "Open",
+					"onClick": map[string]interface{}{
+						"openLink": map[string]interface{}{"url": message.URL},
+					},
+				}},
+			},
+		})
+	}
+
+	// 沒有標題時以嚴重度作為標題，避免顯示空白的 header
+	header := map[string]interface{}{"title": message.Severity.String()}
+	if message.Title != "" {
+		header = map[string]interface{}{
+			"title":    message.Title,
+			"subtitle": message.Severity.String(),
+		}
+	}
+	card := map[string]interface{}{
+		"header":   header,
+		"sections": []map[string]interface{}{{"widgets": widgets}},
+	}
+
+	threadKey := g.ThreadKey
+	if message.Key != "" {
+		threadKey = message.Key
+	}
+
+	return g.post(client, map[string]interface{}{
+		"cardsV2": []map[string]interface{}{{
+			"cardId": "notify",
+			"card":   card,
+		}},
+	}, threadKey)
+}
+
+func (g *googlechat) post(client *http.Client, message map[string]interface{}, threadKey string) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	webhookURL := g.WebhookURL
+	if threadKey != "" {
+		u, err := url.Parse(webhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook url: %v", err)
+		}
+		query := u.Query()
+		query.Set("threadKey", threadKey)
+		query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+		u.RawQuery = query.Encode()
+		webhookURL = u.String()
+	}
+
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	return request(client, req)
+}
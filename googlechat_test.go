@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGoogleChatHeaderFallback(t *testing.T) {
+	var got struct {
+		CardsV2 []struct {
+			Card struct {
+				Header map[string]string `json:"header"`
+			} `json:"card"`
+		} `json:"cardsV2"`
+	}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("threadKey") != "db/down" {
+			t.Errorf("threadKey = %q", r.URL.Query().Get("threadKey"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer done()
+
+	n.GoogleChat("https://chat.googleapis.com/v1/spaces/AAA/messages?key=k&token=t")
+	if err := n.Send(Message{Text: "primary unreachable", Severity: SeverityError, Key: "db/down"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.CardsV2) != 1 {
+		t.Fatalf("payload = %+v", got)
+	}
+	header := got.CardsV2[0].Card.Header
+	if header["title"] != "ERROR" || header["subtitle"] != "" {
+		t.Fatalf("header = %v", header)
+	}
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type GotifyOption func(*gotify)
+
+func GotifyTitle(title string) GotifyOption {
+	return func(g *gotify) { g.Title = title }
+}
+
+func GotifyPriority(priority int) GotifyOption {
+	return func(g *gotify) { g.Priority = &priority }
+}
+
+// GotifyMarkdown 讓客戶端以 markdown 顯示訊息
+func GotifyMarkdown() GotifyOption {
+	return func(g *gotify) { g.Markdown = true }
+}
+
+func GotifyClick(url string) GotifyOption {
+	return func(g *gotify) { g.Click = url }
+}
+
+func (n *Notify) Gotify(serverURL, appToken string, opts ...GotifyOption) *Notify {
+	g := &gotify{
+		ServerURL: strings.TrimRight(serverURL, "/"),
+		AppToken:  appToken,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	n.add(g)
+	return n
+}
+
+type gotify struct {
+	ServerURL string
+	AppToken  string
+	Title     string
+	Priority  *int
+	Markdown  bool
+	Click     string
+}
+
+func (g *gotify) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{
+		"message": message,
+	}
+	if g.Title != "" {
+		payload["title"] = g.Title
+	}
+	if g.Priority != nil {
+		payload["priority"] = *g.Priority
+	}
+
+	extras := map[string]interface{}{}
+	if g.Markdown {
+		extras["client::display"] = map[string]interface{}{"contentType": "text/markdown"}
+	}
+	if g.Click != "" {
+		extras["client::notification"] = map[string]interface{}{
+			"click": map[string]interface{}{"url": g.Click},
+		}
+	}
+	if len(extras) > 0 {
+		payload["extras"] = extras
+	}
+
+	return g.SendRaw(client, payload)
+}
+
+func (g *gotify) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", g.ServerURL+"/message", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", g.AppToken)
+
+	return request(client, req)
+}
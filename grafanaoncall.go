@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GrafanaOnCall 使用 Formatted Webhook 整合的網址
+func (n *Notify) GrafanaOnCall(webhookURL string) *Notify {
+	n.add(&grafanaoncall{
+		WebhookURL: webhookURL,
+	})
+	return n
+}
+
+type grafanaoncall struct {
+	WebhookURL string
+}
+
+func (g *grafanaoncall) Send(client *http.Client, message string) error {
+	return g.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+// SendMessage Key 對應 alert_uid，StatusResolved 送出 state=ok 讓 OnCall 自動恢復
+func (g *grafanaoncall) SendMessage(client *http.Client, message Message) error {
+	state := "alerting"
+	if message.Status == StatusResolved {
+		state = "ok"
+	}
+
+	text := message.Text
+	for _, field := range message.Fields {
+		text += fmt.Sprintf("\n%s: %s", field.Name, field.Value)
+	}
+
+	alert := map[string]interface{}{
+		"title":   fmt.Sprintf("[%s] %s", message.Severity, message.Title),
+		"state":   state,
+		"message": text,
+	}
+	if message.Key != "" {
+		alert["alert_uid"] = message.Key
+	}
+	if message.URL != "" {
+		alert["link_to_upstream_details"] = message.URL
+	}
+
+	return g.SendRaw(client, alert)
+}
+
+func (g *grafanaoncall) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", g.WebhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
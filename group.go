@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Task 包裝 goroutine 或 errgroup 的工作，回傳錯誤或 panic 時通知工作名稱與錯誤；
+// panic 會轉為 error 回傳，context 取消造成的結束不通知：
+//
+//	g.Go(n.Task("consumer", consume))
+func (n *Notify) Task(name string, fn func() error) func() error {
+	return func() (err error) {
+		start := time.Now()
+		severity := SeverityError
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+				severity = SeverityCritical
+			}
+			if err == nil || errors.Is(err, context.Canceled) {
+				return
+			}
+
+			n.Send(Message{
+				Title:    "Task failed: " + name,
+				Text:     err.Error(),
+				Severity: severity,
+				Fields:   []Field{{Name: "uptime", Value: time.Since(start).Round(time.Second).String()}},
+				Key:      "task/" + name,
+			})
+		}()
+		return fn()
+	}
+}
+
+// Group 類似 errgroup.WithContext，每個工作都以 Task 包裝，第一個錯誤會取消 ctx
+type Group struct {
+	notify *Notify
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+func (n *Notify) Group(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{notify: n, cancel: cancel}, ctx
+}
+
+func (g *Group) Go(name string, fn func() error) {
+	task := g.notify.Task(name, fn)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := task(); err != nil {
+			g.once.Do(func() {
+				g.err = fmt.Errorf("%s: %w", name, err)
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// Wait 等待所有工作結束並回傳第一個錯誤
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Healthchecks pingURL 例如 https://hc-ping.com/<uuid> 或 https://hc-ping.com/<ping-key>/<slug>，
+// 自架時換成自己的網址
+func (n *Notify) Healthchecks(pingURL string) *Notify {
+	n.add(&healthchecks{
+		PingURL: strings.TrimSuffix(pingURL, "/"),
+	})
+	return n
+}
+
+type healthchecks struct {
+	PingURL string
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Send 純文字只寫入 log，不改變 check 狀態
+func (h *healthchecks) Send(client *http.Client, message string) error {
+	return h.ping(client, "log", "", message)
+}
+
+// SendMessage Error / Critical 回報失敗，Info 與 Resolved 回報成功，其餘寫入 log；
+// Key 為 UUID 時作為 run ID，對應同一次執行的 start 與結束
+func (h *healthchecks) SendMessage(client *http.Client, message Message) error {
+	state := "log"
+	switch {
+	case message.Status == StatusResolved:
+		state = ""
+	case message.Status == StatusAcknowledged:
+	case message.Severity >= SeverityError:
+		state = "fail"
+	case message.Severity == SeverityInfo:
+		state = ""
+	}
+
+	rid := ""
+	if uuidPattern.MatchString(message.Key) {
+		rid = message.Key
+	}
+	return h.ping(client, state, rid, message.String())
+}
+
+// SendRaw 支援 {"state": "start"|"success"|"fail"|"log"|結束代碼, "rid": run ID, "body": 內容}
+func (h *healthchecks) SendRaw(client *http.Client, message map[string]interface{}) error {
+	state := fmt.Sprint(message["state"])
+	switch state {
+	case "success", "<nil>":
+		state = ""
+	}
+	rid, _ := message["rid"].(string)
+	body, _ := message["body"].(string)
+	return h.ping(client, state, rid, body)
+}
+
+func (h *healthchecks) ping(client *http.Client, state, rid, body string) error {
+	endpoint := h.PingURL
+	if state != "" {
+		endpoint += "/" + state
+	}
+	if rid != "" {
+		endpoint += "?rid=" + url.QueryEscape(rid)
+	}
+
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	return request(client, req)
+}
@@ -0,0 +1,544 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Interaction 使用者按下訊息按鈕的事件
+type Interaction struct {
+	// Platform telegram、discord 或 line
+	Platform string
+	Action   string
+	// Key 原訊息的 Message.Key
+	Key  string
+	User string
+	// UserID 平台的使用者 ID (Telegram 與 Discord 為數字 ID，LINE 為 userId)，使用者無法自行更改
+	UserID string
+	// Text 原訊息內容，LINE 無法取得時為空
+	Text string
+}
+
+// Interactions 接收各平台的按鈕回呼並分派給 On 註冊的函式，
+// 成功後會更新原訊息 (Telegram、Discord) 或回覆一則狀態訊息 (LINE)
+type Interactions struct {
+	Client *http.Client
+
+	mu       sync.Mutex
+	handlers map[string][]func(Interaction) error
+	waiters  map[string]*approvalWaiter
+}
+
+func NewInteractions() *Interactions {
+	return &Interactions{
+		Client:   http.DefaultClient,
+		handlers: map[string][]func(Interaction) error{},
+		waiters:  map[string]*approvalWaiter{},
+	}
+}
+
+// On 註冊 action 的處理函式，action 留空時接收所有按鈕；
+// 回傳錯誤時原訊息不會更新，並將錯誤顯示給按下按鈕的人
+func (i *Interactions) On(action string, fn func(Interaction) error) *Interactions {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers[action] = append(i.handlers[action], fn)
+	return i
+}
+
+func (i *Interactions) dispatch(in Interaction) (bool, error) {
+	i.mu.Lock()
+	if in.Action == ApproveAction.ID || in.Action == DenyAction.ID {
+		waiter, ok := i.waiters[in.Key]
+		if ok && !waiter.allowed(in) {
+			i.mu.Unlock()
+			return true, errors.New("you are not allowed to decide this approval")
+		}
+		delete(i.waiters, in.Key)
+		i.mu.Unlock()
+		if !ok {
+			return true, errors.New("approval request expired or already decided")
+		}
+		waiter.result <- in
+		return true, nil
+	}
+	handlers := append(append([]func(Interaction) error{}, i.handlers[in.Action]...), i.handlers[""]...)
+	i.mu.Unlock()
+
+	for _, fn := range handlers {
+		if err := fn(in); err != nil {
+			return true, err
+		}
+	}
+	return len(handlers) > 0, nil
+}
+
+// actionStatus 按下按鈕後附加在原訊息下方的狀態
+func actionStatus(in Interaction) string {
+	switch in.Action {
+	case AckAction.ID:
+		return "✅ Acknowledged by " + in.User
+	case ResolveAction.ID:
+		return "☑️ Resolved by " + in.User
+	case ApproveAction.ID:
+		return "👍 Approved by " + in.User
+	case DenyAction.ID:
+		return "👎 Denied by " + in.User
+	}
+	return in.Action + " by " + in.User
+}
+
+// keepAction 按下後仍保留的按鈕：已按過的移除，Resolve 與審核決定後全部移除
+func keepAction(pressed, other string) bool {
+	switch pressed {
+	case ResolveAction.ID, ApproveAction.ID, DenyAction.ID:
+		return false
+	}
+	return other != pressed
+}
+
+// Telegram callback_data 上限 64 bytes，過長的 key 以雜湊代替，並記錄在本行程內以便還原；
+// 只保留最近 maxActionKeys 個，較舊訊息的按鈕回傳的 Key 會維持雜湊形式
+const maxActionKeys = 1024
+
+var actionKeys = struct {
+	sync.Mutex
+	keys  map[string]string
+	order []string
+}{keys: map[string]string{}}
+
+func actionData(action, key string) string {
+	data := action + ":" + key
+	if len(data) <= 64 {
+		return data
+	}
+	sum := sha256.Sum256([]byte(key))
+	hash := "#" + hex.EncodeToString(sum[:8])
+
+	actionKeys.Lock()
+	defer actionKeys.Unlock()
+	if _, ok := actionKeys.keys[hash]; !ok {
+		actionKeys.keys[hash] = key
+		actionKeys.order = append(actionKeys.order, hash)
+		if len(actionKeys.order) > maxActionKeys {
+			delete(actionKeys.keys, actionKeys.order[0])
+			actionKeys.order = actionKeys.order[1:]
+		}
+	}
+	return action + ":" + hash
+}
+
+func parseActionData(data string) (action, key string, ok bool) {
+	action, key, ok = strings.Cut(data, ":")
+	if strings.HasPrefix(key, "#") {
+		actionKeys.Lock()
+		if original, found := actionKeys.keys[key]; found {
+			key = original
+		}
+		actionKeys.Unlock()
+	}
+	return action, key, ok && action != ""
+}
+
+type telegramCallback struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+	From struct {
+		ID        int64  `json:"id"`
+		Username  string `json:"username"`
+		FirstName string `json:"first_name"`
+	} `json:"from"`
+	Message *struct {
+		MessageID int64  `json:"message_id"`
+		Text      string `json:"text"`
+		// Entities HTML 格式解析後的範圍，編輯時沿用以保留格式
+		Entities []map[string]interface{} `json:"entities"`
+		Chat     struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		ReplyMarkup struct {
+			InlineKeyboard [][]map[string]interface{} `json:"inline_keyboard"`
+		} `json:"reply_markup"`
+	} `json:"message"`
+}
+
+type telegramUpdate struct {
+	UpdateID      int64             `json:"update_id"`
+	CallbackQuery *telegramCallback `json:"callback_query"`
+}
+
+// TelegramWebhook 接收 setWebhook 設定的更新，secretToken 需與 setWebhook 的 secret_token 相同；
+// secretToken 不可留空，否則任何人都能偽造按鈕回呼 (包含核准 RequestApproval)
+func (i *Interactions) TelegramWebhook(botToken, secretToken string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secretToken == "" {
+			http.Error(w, "telegram webhook secret token not configured", http.StatusInternalServerError)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		given := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(secretToken)) != 1 {
+			http.Error(w, "invalid secret token", http.StatusUnauthorized)
+			return
+		}
+
+		var update telegramUpdate
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&update); err != nil {
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+		if update.CallbackQuery != nil {
+			i.telegramCallback(botToken, update.CallbackQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// TelegramPoll 以 getUpdates 長輪詢按鈕回呼，直到 ctx 結束；已設定 webhook 的 bot 無法使用
+func (i *Interactions) TelegramPoll(ctx context.Context, botToken string) error {
+	var offset int64
+	for {
+		query := url.Values{
+			"timeout":         {"30"},
+			"offset":          {strconv.FormatInt(offset, 10)},
+			"allowed_updates": {`["callback_query"]`},
+		}
+		endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/getUpdates?%s", botToken, query.Encode())
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var result struct {
+			Result []telegramUpdate `json:"result"`
+		}
+		if err := requestJSON(i.Client, req, &result); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Println("notify telegram poll error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		for _, update := range result.Result {
+			offset = update.UpdateID + 1
+			if update.CallbackQuery != nil {
+				i.telegramCallback(botToken, update.CallbackQuery)
+			}
+		}
+	}
+}
+
+func (i *Interactions) telegramCallback(botToken string, callback *telegramCallback) {
+	action, key, ok := parseActionData(callback.Data)
+	if !ok {
+		return
+	}
+	in := Interaction{
+		Platform: "telegram",
+		Action:   action,
+		Key:      key,
+		User:     callback.From.Username,
+		UserID:   strconv.FormatInt(callback.From.ID, 10),
+	}
+	if in.User == "" {
+		in.User = callback.From.FirstName
+	}
+	if callback.Message != nil {
+		in.Text = callback.Message.Text
+	}
+
+	answer := map[string]interface{}{"callback_query_id": callback.ID}
+	handled, err := i.dispatch(in)
+	switch {
+	case err != nil:
+		answer["text"] = err.Error()
+		answer["show_alert"] = true
+	case !handled:
+		answer["text"] = "unknown action " + action
+	case callback.Message != nil:
+		var keyboard [][]map[string]interface{}
+		for _, row := range callback.Message.ReplyMarkup.InlineKeyboard {
+			var buttons []map[string]interface{}
+			for _, button := range row {
+				data, _ := button["callback_data"].(string)
+				if other, _, _ := strings.Cut(data, ":"); keepAction(action, other) {
+					buttons = append(buttons, button)
+				}
+			}
+			if len(buttons) > 0 {
+				keyboard = append(keyboard, buttons)
+			}
+		}
+		edit := map[string]interface{}{
+			"chat_id":    callback.Message.Chat.ID,
+			"message_id": callback.Message.MessageID,
+			"text":       in.Text + "\n\n" + actionStatus(in),
+		}
+		if len(callback.Message.Entities) > 0 {
+			edit["entities"] = callback.Message.Entities
+		}
+		if len(keyboard) > 0 {
+			edit["reply_markup"] = map[string]interface{}{"inline_keyboard": keyboard}
+		}
+		if err := i.telegramCall(botToken, "editMessageText", edit); err != nil {
+			log.Println("notify telegram edit error", err)
+		}
+	}
+
+	if err := i.telegramCall(botToken, "answerCallbackQuery", answer); err != nil {
+		log.Println("notify telegram answer error", err)
+	}
+}
+
+func (i *Interactions) telegramCall(botToken, method string, payload map[string]interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://api.telegram.org/bot%s/%s", botToken, method), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(i.Client, req)
+}
+
+// DiscordHandler Discord 應用程式的 Interactions Endpoint URL，publicKey 為應用程式的 hex 公鑰；
+// Discord 要求 3 秒內回應，處理函式不宜執行太久
+func (i *Interactions) DiscordHandler(publicKey string) http.Handler {
+	key, keyErr := hex.DecodeString(publicKey)
+	if keyErr == nil && len(key) != ed25519.PublicKeySize {
+		keyErr = fmt.Errorf("invalid discord public key length %d", len(key))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if keyErr != nil {
+			http.Error(w, keyErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+		timestamp := r.Header.Get("X-Signature-Timestamp")
+		if err != nil || !ed25519.Verify(key, append([]byte(timestamp), body...), signature) {
+			http.Error(w, "invalid request signature", http.StatusUnauthorized)
+			return
+		}
+
+		var interaction struct {
+			Type int `json:"type"`
+			Data struct {
+				CustomID string `json:"custom_id"`
+			} `json:"data"`
+			Member *struct {
+				User discordUser `json:"user"`
+			} `json:"member"`
+			User    *discordUser `json:"user"`
+			Message struct {
+				Content string `json:"content"`
+				Embeds  []struct {
+					Title       string `json:"title"`
+					Description string `json:"description"`
+				} `json:"embeds"`
+				Components []struct {
+					Type       int                      `json:"type"`
+					Components []map[string]interface{} `json:"components"`
+				} `json:"components"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			http.Error(w, "invalid interaction", http.StatusBadRequest)
+			return
+		}
+
+		// 1: PING，3: MESSAGE_COMPONENT
+		var response map[string]interface{}
+		switch interaction.Type {
+		case 1:
+			response = map[string]interface{}{"type": 1}
+		case 3:
+			action, key, ok := parseActionData(interaction.Data.CustomID)
+			if !ok {
+				response = discordEphemeral("unknown action")
+				break
+			}
+			in := Interaction{Platform: "discord", Action: action, Key: key, Text: interaction.Message.Content}
+			if in.Text == "" && len(interaction.Message.Embeds) > 0 {
+				// SendMessage 以 embed 送出時 content 為空
+				embed := interaction.Message.Embeds[0]
+				in.Text = strings.TrimSpace(embed.Title + "\n" + embed.Description)
+			}
+			if interaction.Member != nil {
+				in.User, in.UserID = interaction.Member.User.Username, interaction.Member.User.ID
+			} else if interaction.User != nil {
+				in.User, in.UserID = interaction.User.Username, interaction.User.ID
+			}
+
+			handled, err := i.dispatch(in)
+			if err != nil {
+				response = discordEphemeral(err.Error())
+				break
+			}
+			if !handled {
+				response = discordEphemeral("unknown action " + action)
+				break
+			}
+
+			components := []interface{}{}
+			for _, row := range interaction.Message.Components {
+				var buttons []map[string]interface{}
+				for _, button := range row.Components {
+					data, _ := button["custom_id"].(string)
+					if other, _, _ := strings.Cut(data, ":"); keepAction(action, other) {
+						buttons = append(buttons, button)
+					}
+				}
+				if len(buttons) > 0 {
+					components = append(components, map[string]interface{}{"type": row.Type, "components": buttons})
+				}
+			}
+			// 7: UPDATE_MESSAGE
+			response = map[string]interface{}{
+				"type": 7,
+				"data": map[string]interface{}{
+					"content":    strings.TrimSpace(interaction.Message.Content + "\n\n" + actionStatus(in)),
+					"components": components,
+				},
+			}
+		default:
+			http.Error(w, "unsupported interaction type", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+type discordUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// discordEphemeral 只有按下按鈕的人看得到的回覆
+func discordEphemeral(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": 4,
+		"data": map[string]interface{}{"content": text, "flags": 64},
+	}
+}
+
+// LineWebhook 接收 LINE Messaging API 的 postback 事件；LINE 訊息無法編輯，改以回覆訊息告知狀態。
+// channelSecret 不可留空，否則任何人都能以空金鑰計算簽章偽造回呼
+func (i *Interactions) LineWebhook(channelSecret, accessToken string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if channelSecret == "" {
+			http.Error(w, "line channel secret not configured", http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		mac := hmac.New(sha256.New, []byte(channelSecret))
+		mac.Write(body)
+		signature, err := base64.StdEncoding.DecodeString(r.Header.Get("X-Line-Signature"))
+		if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+			http.Error(w, "invalid request signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload struct {
+			Events []struct {
+				Type       string `json:"type"`
+				ReplyToken string `json:"replyToken"`
+				Source     struct {
+					UserID string `json:"userId"`
+				} `json:"source"`
+				Postback struct {
+					Data string `json:"data"`
+				} `json:"postback"`
+			} `json:"events"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		for _, event := range payload.Events {
+			if event.Type != "postback" {
+				continue
+			}
+			action, key, ok := parseActionData(event.Postback.Data)
+			if !ok {
+				continue
+			}
+			in := Interaction{Platform: "line", Action: action, Key: key, User: event.Source.UserID, UserID: event.Source.UserID}
+
+			handled, err := i.dispatch(in)
+			if !handled && err == nil {
+				continue
+			}
+			text := actionStatus(in)
+			if err != nil {
+				text = err.Error()
+			}
+			if err := i.lineReply(accessToken, event.ReplyToken, text); err != nil {
+				log.Println("notify line reply error", err)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (i *Interactions) lineReply(accessToken, replyToken, text string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"replyToken": replyToken,
+		"messages":   []interface{}{map[string]interface{}{"type": "text", "text": text}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.line.me/v2/bot/message/reply", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	return request(i.Client, req)
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTelegramWebhookRequiresSecret(t *testing.T) {
+	i := NewInteractions()
+	body := `{"update_id":1,"callback_query":{"id":"1","data":"approve:deploy"}}`
+
+	for _, tc := range []struct {
+		secret, given string
+		want          int
+	}{
+		{"", "", http.StatusInternalServerError},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"s3cret", "wrong", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tc.given)
+		rec := httptest.NewRecorder()
+		i.TelegramWebhook("token", tc.secret).ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("secret %q given %q: status %d, want %d", tc.secret, tc.given, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestLineWebhookRequiresSecret(t *testing.T) {
+	i := NewInteractions()
+	body := `{"events":[{"type":"postback","replyToken":"r","postback":{"data":"approve:deploy"}}]}`
+
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	for _, tc := range []struct {
+		secret, given string
+		want          int
+	}{
+		{"", sign(""), http.StatusInternalServerError},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"s3cret", sign("wrong"), http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("X-Line-Signature", tc.given)
+		rec := httptest.NewRecorder()
+		i.LineWebhook(tc.secret, "token").ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("secret %q given %q: status %d, want %d", tc.secret, tc.given, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestActionDataLongKeys(t *testing.T) {
+	key := strings.Repeat("k", 80)
+	data := actionData("ack", key)
+	if len(data) > 64 {
+		t.Fatalf("callback data %q exceeds 64 bytes", data)
+	}
+	if action, got, ok := parseActionData(data); !ok || action != "ack" || got != key {
+		t.Fatalf("parseActionData(%q) = %q, %q, %v", data, action, got, ok)
+	}
+
+	for n := 0; n < maxActionKeys+10; n++ {
+		actionData("ack", key+strconv.Itoa(n))
+	}
+	actionKeys.Lock()
+	size := len(actionKeys.keys)
+	actionKeys.Unlock()
+	if size > maxActionKeys {
+		t.Fatalf("actionKeys holds %d entries, want at most %d", size, maxActionKeys)
+	}
+}
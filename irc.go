@@ -0,0 +1,237 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type IRCOption func(*irc)
+
+func IRCTLS() IRCOption {
+	return func(i *irc) { i.TLS = true }
+}
+
+// IRCPassword 伺服器密碼 (PASS)
+func IRCPassword(password string) IRCOption {
+	return func(i *irc) { i.Password = password }
+}
+
+func IRCChannelKey(key string) IRCOption {
+	return func(i *irc) { i.ChannelKey = key }
+}
+
+// IRCKeepAlive 維持長連線並自動回應 PING，預設每次發送都重新連線
+func IRCKeepAlive() IRCOption {
+	return func(i *irc) { i.KeepAlive = true }
+}
+
+// IRC server 格式為 host:port
+func (n *Notify) IRC(server, nick, channel string, opts ...IRCOption) *Notify {
+	i := &irc{
+		Server:  server,
+		Nick:    nick,
+		Channel: channel,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	n.add(i)
+	return n
+}
+
+type irc struct {
+	Server     string
+	Nick       string
+	Channel    string
+	ChannelKey string
+	Password   string
+	TLS        bool
+	KeepAlive  bool
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (i *irc) Send(_ *http.Client, message string) error {
+	return i.send(i.Channel, ircLines(message))
+}
+
+// SendRaw 支援 {"target": 頻道或暱稱, "text": 內容}
+func (i *irc) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	text, _ := message["text"].(string)
+	target := i.Channel
+	if value, ok := message["target"].(string); ok {
+		// target 含空白或換行時會被當成額外的參數或指令
+		if value == "" || strings.ContainsAny(value, " \t\r\n\x00") {
+			return fmt.Errorf("invalid irc target %q", value)
+		}
+		target = value
+	}
+	return i.send(target, ircLines(text))
+}
+
+// ircLines 依換行拆成多則 PRIVMSG，並移除殘留的 \r 避免被伺服器視為指令結尾
+func ircLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.ReplaceAll(line, "\r", ""); line != "" {
+			lines = append(lines, splitIRCLine(line, 400)...)
+		}
+	}
+	return lines
+}
+
+func (i *irc) send(target string, lines []string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	err := i.privmsg(target, lines)
+	if err != nil && i.KeepAlive {
+		// 長連線可能已被伺服器斷開，重連一次
+		i.close()
+		err = i.privmsg(target, lines)
+	}
+	if !i.KeepAlive {
+		i.close()
+	}
+
+	return err
+}
+
+func (i *irc) privmsg(target string, lines []string) error {
+	if err := i.connect(); err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(i.conn, "PRIVMSG %s :%s\r\n", target, line); err != nil {
+			return fmt.Errorf("failed to write irc message: %v", err)
+		}
+		// 避免觸發伺服器的 flood 限制
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return nil
+}
+
+func (i *irc) connect() error {
+	if i.conn != nil {
+		return nil
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if i.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", i.Server, &tls.Config{
+			ServerName: strings.Split(i.Server, ":")[0],
+		})
+	} else {
+		conn, err = dialer.Dial("tcp", i.Server)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect irc server: %v", err)
+	}
+
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	if i.Password != "" {
+		fmt.Fprintf(conn, "PASS %s\r\n", i.Password)
+	}
+	fmt.Fprintf(conn, "NICK %s\r\n", i.Nick)
+	fmt.Fprintf(conn, "USER %s 0 * :%s\r\n", i.Nick, i.Nick)
+
+	reader := bufio.NewReader(conn)
+	if err := waitIRCWelcome(conn, reader); err != nil {
+		conn.Close()
+		return err
+	}
+
+	if i.ChannelKey != "" {
+		fmt.Fprintf(conn, "JOIN %s %s\r\n", i.Channel, i.ChannelKey)
+	} else {
+		fmt.Fprintf(conn, "JOIN %s\r\n", i.Channel)
+	}
+	conn.SetDeadline(time.Time{})
+
+	i.conn = conn
+	go i.readLoop(conn, reader)
+	return nil
+}
+
+// waitIRCWelcome 等待 001 (RPL_WELCOME)，期間需回應 PING
+func waitIRCWelcome(conn net.Conn, reader *bufio.Reader) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to register irc connection: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[1] {
+		case "001":
+			return nil
+		case "432", "433", "436", "464", "465":
+			return fmt.Errorf("irc registration rejected: %s", line)
+		}
+		if fields[0] == "ERROR" {
+			return errors.New("irc server closed connection: " + line)
+		}
+	}
+}
+
+func (i *irc) readLoop(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(conn, "PONG%s\r\n", strings.TrimRight(strings.TrimPrefix(line, "PING"), "\r\n"))
+		}
+	}
+}
+
+func (i *irc) close() {
+	if i.conn != nil {
+		fmt.Fprintf(i.conn, "QUIT :bye\r\n")
+		i.conn.Close()
+		i.conn = nil
+	}
+}
+
+// splitIRCLine IRC 單行上限 512 bytes（含指令），依 rune 邊界切分
+func splitIRCLine(line string, size int) []string {
+	var parts []string
+	for len(line) > size {
+		cut := size
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		parts = append(parts, line[:cut])
+		line = line[cut:]
+	}
+	if line != "" {
+		parts = append(parts, line)
+	}
+	return parts
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
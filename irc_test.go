@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIRC 在 USER 之後回覆 001，記錄 client 送出的每一行直到 QUIT
+func fakeIRC(t *testing.T) (string, func() []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	var lines []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			lines = append(lines, line)
+			mu.Unlock()
+			switch {
+			case strings.HasPrefix(line, "USER "):
+				conn.Write([]byte(":irc.test 001 notify :Welcome\r\n"))
+			case strings.HasPrefix(line, "QUIT "):
+				return
+			}
+		}
+	}()
+
+	return listener.Addr().String(), func() []string {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("client did not quit")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return lines
+	}
+}
+
+func TestIRCSendRawStripsLineBreaks(t *testing.T) {
+	addr, transcript := fakeIRC(t)
+	n := New().IRC(addr, "notify", "#ops")
+	if err := n.Send(map[string]interface{}{"target": "alice", "text": "disk full\r\nQUIT :x\rJOIN #admin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "NICK notify\r\n" +
+		"USER notify 0 * :notify\r\n" +
+		"JOIN #ops\r\n" +
+		"PRIVMSG alice :disk full\r\n" +
+		"PRIVMSG alice :QUIT :xJOIN #admin\r\n" +
+		"QUIT :bye\r\n"
+	if got := strings.Join(transcript(), ""); got != want {
+		t.Fatalf("transcript =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestIRCSendRawInvalidTarget(t *testing.T) {
+	for _, target := range []string{"", "alice bob", "alice\r\nQUIT", "#ops\n"} {
+		err := New().IRC("127.0.0.1:1", "notify", "#ops").Send(map[string]interface{}{"target": target, "text": "hi"})
+		if err == nil || !strings.Contains(err.Error(), "invalid irc target") {
+			t.Errorf("target %q: err = %v", target, err)
+		}
+	}
+}
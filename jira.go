@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type JiraOption func(*jira)
+
+// JiraBasicAuth Jira Cloud 使用帳號 email 與 API token
+func JiraBasicAuth(email, apiToken string) JiraOption {
+	return func(j *jira) {
+		j.Username = email
+		j.Password = apiToken
+	}
+}
+
+// JiraBearer Jira Server / Data Center 的 personal access token
+func JiraBearer(token string) JiraOption {
+	return func(j *jira) { j.Token = token }
+}
+
+// JiraIssueType 預設 Task
+func JiraIssueType(issueType string) JiraOption {
+	return func(j *jira) { j.IssueType = issueType }
+}
+
+func JiraLabels(labels ...string) JiraOption {
+	return func(j *jira) { j.Labels = append(j.Labels, labels...) }
+}
+
+// JiraCommentOn 一律在指定 issue 新增留言，不建立新 issue
+func JiraCommentOn(issueKey string) JiraOption {
+	return func(j *jira) { j.IssueKey = issueKey }
+}
+
+// Jira 建立 issue；Message.Key 有值時會先尋找同一 key 尚未完成的 issue 並改為留言
+func (n *Notify) Jira(baseURL, project string, opts ...JiraOption) *Notify {
+	j := &jira{
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		Project:   project,
+		IssueType: "Task",
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	n.add(j)
+	return n
+}
+
+type jira struct {
+	BaseURL   string
+	Project   string
+	Username  string
+	Password  string
+	Token     string
+	IssueType string
+	Labels    []string
+	IssueKey  string
+}
+
+func (j *jira) Send(client *http.Client, message string) error {
+	if j.IssueKey != "" {
+		return j.comment(client, j.IssueKey, message)
+	}
+	return j.create(client, subjectOf(message), message, nil)
+}
+
+func (j *jira) SendMessage(client *http.Client, message Message) error {
+	body := message.String()
+
+	if j.IssueKey != "" {
+		return j.comment(client, j.IssueKey, body)
+	}
+	if message.Key == "" {
+		return j.create(client, message.Title, body, nil)
+	}
+
+	label := "notify-" + sha256Hex([]byte(message.Key))[:12]
+	issueKey, err := j.search(client, label)
+	if err != nil {
+		return err
+	}
+	if issueKey != "" {
+		return j.comment(client, issueKey, body)
+	}
+	if message.Status == StatusResolved {
+		// 沒有對應的 issue，不需要為恢復通知另開 issue
+		return nil
+	}
+	return j.create(client, message.Title, body, []string{label})
+}
+
+// SendRaw 傳入 create issue 的 fields 物件，未指定 project / issuetype / labels 時使用設定值
+func (j *jira) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["project"]; !ok {
+		message["project"] = map[string]string{"key": j.Project}
+	}
+	if _, ok := message["issuetype"]; !ok {
+		message["issuetype"] = map[string]string{"name": j.IssueType}
+	}
+	if _, ok := message["labels"]; !ok && len(j.Labels) > 0 {
+		message["labels"] = j.Labels
+	}
+	return j.post(client, "/rest/api/2/issue", map[string]interface{}{"fields": message})
+}
+
+func (j *jira) create(client *http.Client, summary, description string, labels []string) error {
+	if summary == "" {
+		summary = subjectOf(description)
+	}
+	// summary 上限 255 字且不可換行
+	summary = strings.ReplaceAll(summary, "\n", " ")
+	if r := []rune(summary); len(r) > 255 {
+		summary = string(r[:255])
+	}
+
+	fields := map[string]interface{}{
+		"summary":     summary,
+		"description": description,
+	}
+	if labels = append(append([]string{}, j.Labels...), labels...); len(labels) > 0 {
+		fields["labels"] = labels
+	}
+	return j.SendRaw(client, fields)
+}
+
+func (j *jira) comment(client *http.Client, issueKey, body string) error {
+	return j.post(client, "/rest/api/2/issue/"+url.PathEscape(issueKey)+"/comment", map[string]interface{}{
+		"body": body,
+	})
+}
+
+// search 找出帶有 label 且尚未完成的 issue，Cloud 使用 /search/jql，舊版 Server 退回 /search
+func (j *jira) search(client *http.Client, label string) (string, error) {
+	query := url.Values{}
+	query.Set("jql", fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done ORDER BY created DESC`, j.Project, label))
+	query.Set("maxResults", "1")
+	query.Set("fields", "key")
+
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	for _, path := range []string{"/rest/api/2/search/jql", "/rest/api/2/search"} {
+		req, err := http.NewRequest("GET", j.BaseURL+path+"?"+query.Encode(), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request: %v", err)
+		}
+		j.authorize(req)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to send request: %v", err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			continue
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			err := newAPIError(req, resp)
+			resp.Body.Close()
+			return "", err
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to decode response: %v", err)
+		}
+		break
+	}
+
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+func (j *jira) post(client *http.Client, path string, payload map[string]interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", j.BaseURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	j.authorize(req)
+
+	return request(client, req)
+}
+
+func (j *jira) authorize(req *http.Request) {
+	req.Header.Set("Accept", "application/json")
+	if j.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+j.Token)
+	} else if j.Username != "" {
+		req.SetBasicAuth(j.Username, j.Password)
+	}
+}
@@ -0,0 +1,168 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+type JobOption func(*Job)
+
+// JobNotifyStart 開始執行時也送出通知，預設只通知結果
+func JobNotifyStart() JobOption {
+	return func(j *Job) { j.NotifyStart = true }
+}
+
+// JobFailureOnly 只在失敗 (以及失敗後恢復) 時通知
+func JobFailureOnly() JobOption {
+	return func(j *Job) { j.FailureOnly = true }
+}
+
+// JobTimeout 超過時間即取消 ctx，fn 應自行檢查 ctx.Done()
+func JobTimeout(timeout time.Duration) JobOption {
+	return func(j *Job) { j.Timeout = timeout }
+}
+
+// JobTailLines 失敗時附上最後幾行輸出，預設 20 行
+func JobTailLines(lines int) JobOption {
+	return func(j *Job) { j.TailLines = lines }
+}
+
+// Job 包裝排程工作，量測執行時間並通知成功或失敗 (含錯誤與輸出尾段)；
+// fn 寫入 output 的內容會保留最後幾行。Run 符合 robfig/cron 的 cron.Job 介面：
+//
+//	c.AddJob("0 4 * * *", n.Job("backup", backup))
+func (n *Notify) Job(name string, fn func(ctx context.Context, output io.Writer) error, opts ...JobOption) *Job {
+	j := &Job{
+		Name:      name,
+		TailLines: 20,
+		notify:    n,
+		fn:        fn,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+type Job struct {
+	Name        string
+	NotifyStart bool
+	FailureOnly bool
+	Timeout     time.Duration
+	TailLines   int
+
+	notify *Notify
+	fn     func(ctx context.Context, output io.Writer) error
+
+	mu         sync.Mutex
+	lastFailed bool
+}
+
+// Run 供排程器呼叫，錯誤已透過通知送出
+func (j *Job) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext 執行一次並回傳 fn 的錯誤，fn panic 時視為失敗
+func (j *Job) RunContext(ctx context.Context) (err error) {
+	if j.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.Timeout)
+		defer cancel()
+	}
+
+	key := "job/" + j.Name
+	if j.NotifyStart && !j.FailureOnly {
+		j.notify.Send(Message{
+			Title:    "Job started: " + j.Name,
+			Severity: SeverityInfo,
+		})
+	}
+
+	output := &tailWriter{lines: j.TailLines}
+	start := time.Now()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+			}
+		}()
+		err = j.fn(ctx, output)
+	}()
+	duration := time.Since(start).Round(time.Millisecond)
+
+	j.mu.Lock()
+	lastFailed := j.lastFailed
+	j.lastFailed = err != nil
+	j.mu.Unlock()
+
+	fields := []Field{{Name: "duration", Value: duration.String()}}
+	if err != nil {
+		text := err.Error()
+		if tail := output.String(); tail != "" {
+			text += "\n\n" + tail
+		}
+		j.notify.Send(Message{
+			Title:    "Job failed: " + j.Name,
+			Text:     text,
+			Severity: SeverityError,
+			Fields:   fields,
+			Key:      key,
+		})
+		return err
+	}
+
+	if lastFailed {
+		j.notify.Send(Message{
+			Title:    "Job recovered: " + j.Name,
+			Severity: SeverityInfo,
+			Status:   StatusResolved,
+			Fields:   fields,
+			Key:      key,
+		})
+	} else if !j.FailureOnly {
+		j.notify.Send(Message{
+			Title:    "Job succeeded: " + j.Name,
+			Severity: SeverityInfo,
+			Fields:   fields,
+		})
+	}
+	return nil
+}
+
+// tailWriter 只保留最後幾行輸出
+type tailWriter struct {
+	lines int
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = append(t.buf, p...)
+	if count := bytes.Count(t.buf, []byte("\n")); count > t.lines {
+		for i := 0; i < count-t.lines; i++ {
+			t.buf = t.buf[bytes.IndexByte(t.buf, '\n')+1:]
+		}
+	}
+	// 沒有換行的長輸出也限制大小
+	if len(t.buf) > 4096 {
+		t.buf = t.buf[len(t.buf)-4096:]
+	}
+	return len(p), nil
+}
+
+func (t *tailWriter) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return strings.TrimRight(string(t.buf), "\n")
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// signJWT 簽署 JWT，支援 RS256 (*rsa.PrivateKey) 與 ES256 (*ecdsa.PrivateKey)
+func signJWT(header, claims map[string]interface{}, key crypto.Signer) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		header["alg"] = "RS256"
+	case *ecdsa.PrivateKey:
+		header["alg"] = "ES256"
+	default:
+		return "", errors.New("unsupported JWT signing key")
+	}
+	header["typ"] = "JWT"
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %v", err)
+	}
+	claimsData, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %v", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerData) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsData)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("failed to sign JWT: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		// JWS 的 ES256 簽章為固定長度 r||s，不是 ASN.1
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("failed to sign JWT: %v", err)
+		}
+		signature = append(padBigInt(r, 32), padBigInt(s, 32)...)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func padBigInt(n *big.Int, size int) []byte {
+	out := make([]byte, size)
+	return n.FillBytes(out)
+}
+
+// parsePrivateKey 解析 PEM 格式的 PKCS#8、PKCS#1 或 EC 私鑰
+func parsePrivateKey(pemData []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("failed to parse private key")
+}
@@ -0,0 +1,537 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// KafkaAvroSchema KafkaAvro 使用的 schema，需先註冊到 Schema Registry 取得 schema ID
+const KafkaAvroSchema = `{"type":"record","name":"Notification","namespace":"notify","fields":[` +
+	`{"name":"timestamp","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+	`{"name":"severity","type":"string"},{"name":"status","type":"string"},` +
+	`{"name":"title","type":"string"},{"name":"text","type":"string"},` +
+	`{"name":"fields","type":{"type":"map","values":"string"}},` +
+	`{"name":"url","type":"string"},{"name":"key","type":"string"}]}`
+
+type KafkaOption func(*kafka)
+
+func KafkaTLS(config *tls.Config) KafkaOption {
+	return func(k *kafka) { k.TLSConfig = config }
+}
+
+// KafkaSASLPlain SASL/PLAIN 驗證，通常搭配 KafkaTLS 使用 (例如 Confluent Cloud)
+func KafkaSASLPlain(username, password string) KafkaOption {
+	return func(k *kafka) {
+		k.Username = username
+		k.Password = password
+	}
+}
+
+// KafkaAvro 以 Confluent wire format (magic byte + schema ID) 的 Avro 編碼取代 JSON
+func KafkaAvro(schemaID int32) KafkaOption {
+	return func(k *kafka) { k.AvroSchemaID = &schemaID }
+}
+
+// Kafka 發佈訊息到 topic，Message.Key 作為 record key 以維持同一事件的分區順序
+func (n *Notify) Kafka(brokers []string, topic string, opts ...KafkaOption) *Notify {
+	k := &kafka{
+		Brokers: brokers,
+		Topic:   topic,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	n.add(k)
+	return n
+}
+
+type kafka struct {
+	Brokers      []string
+	Topic        string
+	TLSConfig    *tls.Config
+	Username     string
+	Password     string
+	AvroSchemaID *int32
+
+	next uint32
+}
+
+func (k *kafka) Send(client *http.Client, message string) error {
+	return k.SendMessage(client, Message{Text: message})
+}
+
+func (k *kafka) SendMessage(_ *http.Client, message Message) error {
+	var value []byte
+	if k.AvroSchemaID != nil {
+		value = kafkaAvroEncode(*k.AvroSchemaID, message)
+	} else {
+		record := messageRecord(message)
+		record.Timestamp = time.Now().UTC()
+		jsonData, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		value = jsonData
+	}
+
+	var key []byte
+	if message.Key != "" {
+		key = []byte(message.Key)
+	}
+	return k.produce(key, value)
+}
+
+// SendRaw 一律以 JSON 送出，"key" 欄位作為 record key
+func (k *kafka) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	var key []byte
+	if s, ok := message["key"].(string); ok && s != "" {
+		key = []byte(s)
+	}
+	return k.produce(key, jsonData)
+}
+
+func (k *kafka) produce(key, value []byte) error {
+	var errs []error
+	for _, broker := range k.Brokers {
+		err := k.produceVia(broker, key, value)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("kafka %s: %w", broker, err))
+	}
+	if len(errs) == 0 {
+		return errors.New("no kafka brokers configured")
+	}
+	return errors.Join(errs...)
+}
+
+func (k *kafka) produceVia(bootstrap string, key, value []byte) error {
+	conn, err := k.dial(bootstrap)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	brokers, partitions, err := k.metadata(conn)
+	if err != nil {
+		return err
+	}
+
+	var partition int32
+	if key != nil {
+		partition = int32(murmur2(key)&0x7fffffff) % int32(len(partitions))
+	} else {
+		partition = int32(atomic.AddUint32(&k.next, 1) % uint32(len(partitions)))
+	}
+	p := partitions[partition]
+	if p.ErrorCode != 0 || p.Leader < 0 {
+		return fmt.Errorf("partition %d unavailable: error code %d", p.Index, p.ErrorCode)
+	}
+
+	leader, ok := brokers[p.Leader]
+	if !ok {
+		return fmt.Errorf("unknown leader %d for partition %d", p.Leader, p.Index)
+	}
+	if leader != conn.address {
+		leaderConn, err := k.dial(leader)
+		if err != nil {
+			return err
+		}
+		defer leaderConn.Close()
+		conn = leaderConn
+	}
+
+	return k.producePartition(conn, p.Index, key, value)
+}
+
+type kafkaPartition struct {
+	ErrorCode int16
+	Index     int32
+	Leader    int32
+}
+
+// metadata Metadata v1，回傳 broker 位址與 topic 的分區資訊
+func (k *kafka) metadata(conn *kafkaConn) (map[int32]string, []kafkaPartition, error) {
+	var body kafkaBuffer
+	body.int32(1)
+	body.string(k.Topic)
+
+	resp, err := conn.roundTrip(3, 1, body.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &kafkaReader{b: resp}
+	brokers := map[int32]string{}
+	for i := r.int32(); i > 0; i-- {
+		id := r.int32()
+		host := r.string()
+		port := r.int32()
+		r.string() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	r.int32() // controller id
+
+	var partitions []kafkaPartition
+	for i := r.int32(); i > 0; i-- {
+		errorCode := r.int16()
+		name := r.string()
+		r.int8() // is internal
+		for j := r.int32(); j > 0; j-- {
+			p := kafkaPartition{ErrorCode: r.int16(), Index: r.int32(), Leader: r.int32()}
+			r.skipInt32Array() // replicas
+			r.skipInt32Array() // isr
+			if name == k.Topic {
+				partitions = append(partitions, p)
+			}
+		}
+		if name == k.Topic && errorCode != 0 {
+			return nil, nil, fmt.Errorf("topic %s metadata error code %d", k.Topic, errorCode)
+		}
+	}
+	if r.err != nil {
+		return nil, nil, fmt.Errorf("failed to decode metadata: %v", r.err)
+	}
+	if len(partitions) == 0 {
+		return nil, nil, fmt.Errorf("topic %s has no partitions", k.Topic)
+	}
+
+	// 依 partition index 排序，key 才能穩定對應到同一分區
+	sorted := make([]kafkaPartition, len(partitions))
+	for _, p := range partitions {
+		if p.Index < 0 || int(p.Index) >= len(sorted) {
+			return nil, nil, fmt.Errorf("unexpected partition index %d", p.Index)
+		}
+		sorted[p.Index] = p
+	}
+	return brokers, sorted, nil
+}
+
+// producePartition Produce v3，acks=all，單筆 RecordBatch v2
+func (k *kafka) producePartition(conn *kafkaConn, partition int32, key, value []byte) error {
+	batch := kafkaRecordBatch(key, value, time.Now())
+
+	var body kafkaBuffer
+	body.int16(-1) // transactional id
+	body.int16(-1) // acks
+	body.int32(10000)
+	body.int32(1)
+	body.string(k.Topic)
+	body.int32(1)
+	body.int32(partition)
+	body.bytes(batch)
+
+	resp, err := conn.roundTrip(0, 3, body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	r := &kafkaReader{b: resp}
+	for i := r.int32(); i > 0; i-- {
+		r.string()
+		for j := r.int32(); j > 0; j-- {
+			r.int32()
+			if code := r.int16(); code != 0 {
+				return fmt.Errorf("produce failed: error code %d", code)
+			}
+			r.int64() // base offset
+			r.int64() // log append time
+		}
+	}
+	if r.err != nil {
+		return fmt.Errorf("failed to decode produce response: %v", r.err)
+	}
+	return nil
+}
+
+func kafkaRecordBatch(key, value []byte, now time.Time) []byte {
+	var record kafkaBuffer
+	record.int8(0) // attributes
+	record.varint(0)
+	record.varint(0)
+	if key == nil {
+		record.varint(-1)
+	} else {
+		record.varint(int64(len(key)))
+		record.Write(key)
+	}
+	record.varint(int64(len(value)))
+	record.Write(value)
+	record.varint(0) // headers
+
+	// CRC 涵蓋 attributes 之後的所有內容
+	var tail kafkaBuffer
+	tail.int16(0) // attributes
+	tail.int32(0) // last offset delta
+	tail.int64(now.UnixMilli())
+	tail.int64(now.UnixMilli())
+	tail.int64(-1) // producer id
+	tail.int16(-1) // producer epoch
+	tail.int32(-1) // base sequence
+	tail.int32(1)
+	tail.varint(int64(record.Len()))
+	tail.Write(record.Bytes())
+
+	var batch kafkaBuffer
+	batch.int64(0)
+	batch.int32(int32(4 + 1 + 4 + tail.Len()))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(tail.Bytes(), crc32.MakeTable(crc32.Castagnoli))))
+	batch.Write(tail.Bytes())
+	return batch.Bytes()
+}
+
+// murmur2 與 Java client 預設 partitioner 相同，確保同一 key 落在相同分區
+func murmur2(data []byte) uint32 {
+	const m = 0x5bd1e995
+	length := len(data)
+	h := uint32(0x9747b28c) ^ uint32(length)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> 24
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	rest := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[rest+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[rest+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[rest])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
+
+func kafkaAvroEncode(schemaID int32, message Message) []byte {
+	var b kafkaBuffer
+	b.int8(0)
+	b.int32(schemaID)
+
+	b.varint(time.Now().UnixMilli())
+	avroString(&b, message.Severity.String())
+	avroString(&b, message.Status.String())
+	avroString(&b, message.Title)
+	avroString(&b, message.Text)
+	if len(message.Fields) > 0 {
+		b.varint(int64(len(message.Fields)))
+		for _, field := range message.Fields {
+			avroString(&b, field.Name)
+			avroString(&b, field.Value)
+		}
+	}
+	b.varint(0)
+	avroString(&b, message.URL)
+	avroString(&b, message.Key)
+	return b.Bytes()
+}
+
+func avroString(b *kafkaBuffer, s string) {
+	b.varint(int64(len(s)))
+	b.WriteString(s)
+}
+
+type kafkaConn struct {
+	net.Conn
+	address     string
+	correlation int32
+}
+
+func (k *kafka) dial(address string) (*kafkaConn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if k.TLSConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, k.TLSConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %v", err)
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	c := &kafkaConn{Conn: conn, address: address}
+	if k.Username != "" {
+		if err := c.saslPlain(k.Username, k.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// saslPlain SaslHandshake v1 + SaslAuthenticate v0
+func (c *kafkaConn) saslPlain(username, password string) error {
+	var body kafkaBuffer
+	body.string("PLAIN")
+	resp, err := c.roundTrip(17, 1, body.Bytes())
+	if err != nil {
+		return err
+	}
+	if r := (&kafkaReader{b: resp}); r.int16() != 0 {
+		return errors.New("kafka broker does not support SASL/PLAIN")
+	}
+
+	body.Reset()
+	body.bytes([]byte("\x00" + username + "\x00" + password))
+	resp, err = c.roundTrip(36, 0, body.Bytes())
+	if err != nil {
+		return err
+	}
+	r := &kafkaReader{b: resp}
+	if code := r.int16(); code != 0 {
+		return fmt.Errorf("kafka SASL authentication failed: %s", r.string())
+	}
+	return nil
+}
+
+func (c *kafkaConn) roundTrip(apiKey, version int16, body []byte) ([]byte, error) {
+	c.correlation++
+
+	var req kafkaBuffer
+	req.int32(0)
+	req.int16(apiKey)
+	req.int16(version)
+	req.int32(c.correlation)
+	req.string("notify-go")
+	req.Write(body)
+	frame := req.Bytes()
+	binary.BigEndian.PutUint32(frame, uint32(len(frame)-4))
+
+	if _, err := c.Write(frame); err != nil {
+		return nil, fmt.Errorf("failed to send request: %v", err)
+	}
+
+	var header [8]byte
+	if _, err := io.ReadFull(c, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size < 4 || size > 64<<20 {
+		return nil, fmt.Errorf("invalid response size %d", size)
+	}
+	if id := int32(binary.BigEndian.Uint32(header[4:])); id != c.correlation {
+		return nil, fmt.Errorf("unexpected correlation id %d", id)
+	}
+
+	resp := make([]byte, size-4)
+	if _, err := io.ReadFull(c, resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	return resp, nil
+}
+
+type kafkaBuffer struct {
+	bytes.Buffer
+}
+
+func (b *kafkaBuffer) int8(v int8) { b.WriteByte(byte(v)) }
+
+func (b *kafkaBuffer) int16(v int16) { b.Write(binary.BigEndian.AppendUint16(nil, uint16(v))) }
+
+func (b *kafkaBuffer) int32(v int32) { b.Write(binary.BigEndian.AppendUint32(nil, uint32(v))) }
+
+func (b *kafkaBuffer) int64(v int64) { b.Write(binary.BigEndian.AppendUint64(nil, uint64(v))) }
+
+// varint zigzag 編碼，Kafka record 與 Avro 相同
+func (b *kafkaBuffer) varint(v int64) { b.Write(binary.AppendVarint(nil, v)) }
+
+func (b *kafkaBuffer) string(s string) {
+	b.int16(int16(len(s)))
+	b.WriteString(s)
+}
+
+func (b *kafkaBuffer) bytes(p []byte) {
+	b.int32(int32(len(p)))
+	b.Write(p)
+}
+
+type kafkaReader struct {
+	b   []byte
+	err error
+}
+
+func (r *kafkaReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.b) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	p := r.b[:n]
+	r.b = r.b[n:]
+	return p
+}
+
+func (r *kafkaReader) int8() int8 {
+	if p := r.take(1); p != nil {
+		return int8(p[0])
+	}
+	return 0
+}
+
+func (r *kafkaReader) int16() int16 {
+	if p := r.take(2); p != nil {
+		return int16(binary.BigEndian.Uint16(p))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int32() int32 {
+	if p := r.take(4); p != nil {
+		return int32(binary.BigEndian.Uint32(p))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int64() int64 {
+	if p := r.take(8); p != nil {
+		return int64(binary.BigEndian.Uint64(p))
+	}
+	return 0
+}
+
+// string nullable string 長度為 -1 時回傳空字串
+func (r *kafkaReader) string() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.take(int(n)))
+}
+
+func (r *kafkaReader) skipInt32Array() {
+	n := r.int32()
+	r.take(int(n) * 4)
+}
@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMurmur2 與 Java client 的 UtilsTest.testMurmur2 相同
+func TestMurmur2(t *testing.T) {
+	tests := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for input, want := range tests {
+		if got := int32(murmur2([]byte(input))); got != want {
+			t.Errorf("murmur2(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func TestKafkaRecordBatch(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	tests := []struct {
+		name string
+		key  []byte
+		want string
+	}{
+		{"key", []byte("k"), "00000000000000000000003affffffff02e99b8dd80000000000000000018bcfe568000000018bcfe56800ffffffffffffffffffffffffffff0000000110000000026b027600"},
+		{"null key", nil, "000000000000000000000039ffffffff02d3f2ac750000000000000000018bcfe568000000018bcfe56800ffffffffffffffffffffffffffff000000010e00000001027600"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(kafkaRecordBatch(tt.key, []byte("v"), now)); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKafkaAvroEncode(t *testing.T) {
+	data := kafkaAvroEncode(7, Message{
+		Title:    "t",
+		Text:     "x",
+		Severity: SeverityError,
+		Fields:   []Field{{Name: "a", Value: "b"}},
+		Key:      "k",
+	})
+
+	// magic byte + schema ID
+	if !bytes.Equal(data[:5], []byte{0, 0, 0, 0, 7}) {
+		t.Fatalf("header = %x", data[:5])
+	}
+	// 略過 timestamp (long)
+	_, n := binary.Varint(data[5:])
+	if n <= 0 {
+		t.Fatal("invalid timestamp")
+	}
+
+	var want bytes.Buffer
+	for _, s := range []string{SeverityError.String(), StatusFiring.String(), "t", "x"} {
+		want.Write(binary.AppendVarint(nil, int64(len(s))))
+		want.WriteString(s)
+	}
+	want.Write([]byte{0x02, 0x02, 'a', 0x02, 'b', 0x00}) // map: 1 個 block、"a" -> "b"、結尾
+	want.Write([]byte{0x00, 0x02, 'k'})                  // url "", key "k"
+	if got := data[5+n:]; !bytes.Equal(got, want.Bytes()) {
+		t.Fatalf("body =\n%x\nwant\n%x", got, want.Bytes())
+	}
+}
+
+// fakeKafkaBroker 回應 Metadata v1 與 Produce v3，記錄收到的分區與 record batch
+type fakeKafkaBroker struct {
+	listener   net.Listener
+	partitions int32
+
+	mu       sync.Mutex
+	produced map[int32][]byte
+}
+
+func newFakeKafkaBroker(t *testing.T, partitions int32) *fakeKafkaBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &fakeKafkaBroker{listener: listener, partitions: partitions, produced: map[int32][]byte{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return b
+}
+
+func (b *fakeKafkaBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(conn, frame); err != nil {
+			return
+		}
+
+		r := &kafkaReader{b: frame}
+		apiKey := r.int16()
+		r.int16() // version
+		correlation := r.int32()
+		r.string() // client id
+
+		var resp kafkaBuffer
+		resp.int32(0)
+		resp.int32(correlation)
+		switch apiKey {
+		case 3:
+			host, port, _ := net.SplitHostPort(b.listener.Addr().String())
+			portNumber, _ := strconv.Atoi(port)
+			resp.int32(1)
+			resp.int32(0)
+			resp.string(host)
+			resp.int32(int32(portNumber))
+			resp.int16(-1) // rack
+			resp.int32(0)  // controller id
+			resp.int32(1)
+			resp.int16(0)
+			resp.string("alerts")
+			resp.int8(0)
+			resp.int32(b.partitions)
+			for i := int32(0); i < b.partitions; i++ {
+				resp.int16(0)
+				resp.int32(i)
+				resp.int32(0)
+				resp.int32(1)
+				resp.int32(0)
+				resp.int32(1)
+				resp.int32(0)
+			}
+		case 0:
+			r.int16() // transactional id
+			r.int16() // acks
+			r.int32() // timeout
+			r.int32()
+			topic := r.string()
+			r.int32()
+			partition := r.int32()
+			batch := r.take(int(r.int32()))
+			b.mu.Lock()
+			b.produced[partition] = append([]byte(nil), batch...)
+			b.mu.Unlock()
+
+			resp.int32(1)
+			resp.string(topic)
+			resp.int32(1)
+			resp.int32(partition)
+			resp.int16(0)
+			resp.int64(0)
+			resp.int64(-1)
+			resp.int32(0) // throttle time
+		default:
+			return
+		}
+
+		out := resp.Bytes()
+		binary.BigEndian.PutUint32(out, uint32(len(out)-4))
+		if _, err := conn.Write(out); err != nil {
+			return
+		}
+	}
+}
+
+func TestKafkaSendMessage(t *testing.T) {
+	broker := newFakeKafkaBroker(t, 3)
+
+	n := New().Kafka([]string{broker.listener.Addr().String()}, "alerts")
+	if err := n.Send(Message{Text: "disk full", Key: "db-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	partition := int32(murmur2([]byte("db-1"))&0x7fffffff) % 3
+	broker.mu.Lock()
+	batch, ok := broker.produced[partition]
+	broker.mu.Unlock()
+	if !ok {
+		t.Fatalf("nothing produced to partition %d: %v", partition, broker.produced)
+	}
+	if batch[16] != 2 {
+		t.Fatalf("magic = %d", batch[16])
+	}
+	if !bytes.Contains(batch, []byte("db-1")) || !bytes.Contains(batch, []byte(`"text":"disk full"`)) {
+		t.Fatalf("batch missing key or value: %q", batch)
+	}
+}
+
+func TestKafkaNoBrokers(t *testing.T) {
+	err := New().Kafka(nil, "alerts").Send("disk full")
+	if err == nil || !strings.Contains(err.Error(), "no kafka brokers") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type KakaoOption func(*kakao)
+
+// KakaoRefreshToken 提供 refresh token 後會自動更新過期的 access token
+func KakaoRefreshToken(restAPIKey, refreshToken string) KakaoOption {
+	return func(k *kakao) {
+		k.RESTAPIKey = restAPIKey
+		k.RefreshToken = refreshToken
+	}
+}
+
+// KakaoLink 訊息附帶的連結，未設定時使用 https://developers.kakao.com
+func KakaoLink(url string) KakaoOption {
+	return func(k *kakao) { k.Link = url }
+}
+
+// Kakao 使用「傳給自己」(memo) API，accessToken 需具備 talk_message 權限
+func (n *Notify) Kakao(accessToken string, opts ...KakaoOption) *Notify {
+	k := &kakao{
+		accessToken: accessToken,
+		Link:        "https://developers.kakao.com",
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	n.add(k)
+	return n
+}
+
+type kakao struct {
+	RESTAPIKey   string
+	RefreshToken string
+	Link         string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func (k *kakao) Send(client *http.Client, message string) error {
+	// text 樣板上限 200 字
+	if r := []rune(message); len(r) > 200 {
+		message = string(r[:197]) + "..."
+	}
+
+	return k.SendRaw(client, map[string]interface{}{
+		"object_type": "text",
+		"text":        message,
+		"link": map[string]interface{}{
+			"web_url":        k.Link,
+			"mobile_web_url": k.Link,
+		},
+	})
+}
+
+// SendRaw 傳入 template_object 內容
+func (k *kakao) SendRaw(client *http.Client, message map[string]interface{}) error {
+	token, err := k.token(client)
+	if err != nil {
+		return err
+	}
+
+	templateObject, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	form := url.Values{}
+	form.Set("template_object", string(templateObject))
+
+	req, err := http.NewRequest("POST", "https://kapi.kakao.com/v2/api/talk/memo/default/send", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var result struct {
+		ResultCode int `json:"result_code"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.ResultCode != 0 {
+		return fmt.Errorf("kakao responded with result_code %d", result.ResultCode)
+	}
+
+	return nil
+}
+
+func (k *kakao) token(client *http.Client) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.RefreshToken == "" || (k.accessToken != "" && time.Now().Before(k.expiresAt)) {
+		return k.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", k.RESTAPIKey)
+	form.Set("refresh_token", k.RefreshToken)
+
+	req, err := http.NewRequest("POST", "https://kauth.kakao.com/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", fmt.Errorf("failed to refresh kakao token: %v", err)
+	}
+
+	k.accessToken = result.AccessToken
+	k.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - 5*time.Minute)
+	// refresh token 快到期時 Kakao 會一併換發新的
+	if result.RefreshToken != "" {
+		k.RefreshToken = result.RefreshToken
+	}
+	return k.accessToken, nil
+}
@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+)
+
+type KeybaseOption func(*keybase)
+
+// KeybaseBinary keybase 執行檔路徑，預設從 PATH 尋找
+func KeybaseBinary(path string) KeybaseOption {
+	return func(k *keybase) { k.Binary = path }
+}
+
+// Keybase 透過本機已登入的 keybase CLI (keybase chat api) 發送到 team 頻道
+func (n *Notify) Keybase(team, channel string, opts ...KeybaseOption) *Notify {
+	k := &keybase{
+		Binary: "keybase",
+		Channel: map[string]interface{}{
+			"name":         team,
+			"members_type": "team",
+			"topic_name":   channel,
+		},
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	n.add(k)
+	return n
+}
+
+// KeybaseDirect 私訊給使用者，多人以逗號分隔
+func (n *Notify) KeybaseDirect(users string, opts ...KeybaseOption) *Notify {
+	k := &keybase{
+		Binary: "keybase",
+		Channel: map[string]interface{}{
+			"name": users,
+		},
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	n.add(k)
+	return n
+}
+
+type keybase struct {
+	Binary  string
+	Channel map[string]interface{}
+}
+
+func (k *keybase) Send(client *http.Client, message string) error {
+	return k.SendRaw(client, map[string]interface{}{
+		"message": map[string]interface{}{"body": message},
+	})
+}
+
+// SendRaw 傳入 chat api send 的 options 內容
+func (k *keybase) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	if _, ok := message["channel"]; !ok {
+		message["channel"] = k.Channel
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"method": "send",
+		"params": map[string]interface{}{"options": message},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(k.Binary, "chat", "api")
+	cmd.Stdin = bytes.NewReader(jsonData)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run keybase chat api: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	var result struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return fmt.Errorf("failed to decode keybase response: %v", err)
+	}
+	if result.Error != nil {
+		return errors.New("keybase: " + result.Error.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type MailgunOption func(*mailgun)
+
+func MailgunSubject(subject string) MailgunOption {
+	return func(m *mailgun) { m.Subject = subject }
+}
+
+func MailgunTags(tags ...string) MailgunOption {
+	return func(m *mailgun) { m.Tags = append(m.Tags, tags...) }
+}
+
+// MailgunTracking 開關整體追蹤，另可分別控制點擊與開信追蹤
+func MailgunTracking(enabled, clicks, opens bool) MailgunOption {
+	return func(m *mailgun) {
+		m.Tracking = &enabled
+		m.TrackingClicks = &clicks
+		m.TrackingOpens = &opens
+	}
+}
+
+// MailgunEU 使用歐洲區 API endpoint
+func MailgunEU() MailgunOption {
+	return func(m *mailgun) { m.BaseURL = "https://api.eu.mailgun.net" }
+}
+
+func (n *Notify) Mailgun(apiKey, domain, from string, to []string, opts ...MailgunOption) *Notify {
+	m := &mailgun{
+		APIKey:  apiKey,
+		Domain:  domain,
+		From:    from,
+		To:      to,
+		BaseURL: "https://api.mailgun.net",
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	n.add(m)
+	return n
+}
+
+type mailgun struct {
+	APIKey         string
+	Domain         string
+	From           string
+	To             []string
+	Subject        string
+	Tags           []string
+	Tracking       *bool
+	TrackingClicks *bool
+	TrackingOpens  *bool
+	BaseURL        string
+}
+
+func (m *mailgun) Send(client *http.Client, message string) error {
+	form := url.Values{}
+	form.Set("text", message)
+	if m.Subject != "" {
+		form.Set("subject", m.Subject)
+	} else {
+		form.Set("subject", subjectOf(message))
+	}
+
+	return m.post(client, form)
+}
+
+func (m *mailgun) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		switch v := value.(type) {
+		case []string:
+			for _, s := range v {
+				form.Add(key, s)
+			}
+		default:
+			form.Set(key, fmt.Sprint(v))
+		}
+	}
+
+	return m.post(client, form)
+}
+
+func (m *mailgun) post(client *http.Client, form url.Values) error {
+	if !form.Has("from") {
+		form.Set("from", m.From)
+	}
+	if !form.Has("to") {
+		for _, to := range m.To {
+			form.Add("to", to)
+		}
+	}
+	if !form.Has("o:tag") {
+		for _, tag := range m.Tags {
+			form.Add("o:tag", tag)
+		}
+	}
+	if m.Tracking != nil && !form.Has("o:tracking") {
+		form.Set("o:tracking", yesNo(*m.Tracking))
+		form.Set("o:tracking-clicks", yesNo(*m.TrackingClicks))
+		form.Set("o:tracking-opens", yesNo(*m.TrackingOpens))
+	}
+
+	url := fmt.Sprintf("%s/v3/%s/messages", m.BaseURL, m.Domain)
+	req, err := http.NewRequest("POST", url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("api", m.APIKey)
+
+	return request(client, req)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
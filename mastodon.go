@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type MastodonOption func(*mastodon)
+
+// MastodonVisibility public、unlisted、private 或 direct
+func MastodonVisibility(visibility string) MastodonOption {
+	return func(m *mastodon) { m.Visibility = visibility }
+}
+
+// MastodonDirect 以私訊發送給指定帳號，例如 "@ops@mastodon.social"
+func MastodonDirect(accounts ...string) MastodonOption {
+	return func(m *mastodon) {
+		m.Visibility = "direct"
+		m.Mentions = append(m.Mentions, accounts...)
+	}
+}
+
+// MastodonSpoiler 內容警告，內文預設折疊
+func MastodonSpoiler(text string) MastodonOption {
+	return func(m *mastodon) { m.Spoiler = text }
+}
+
+func (n *Notify) Mastodon(instanceURL, accessToken string, opts ...MastodonOption) *Notify {
+	m := &mastodon{
+		InstanceURL: strings.TrimRight(instanceURL, "/"),
+		AccessToken: accessToken,
+		Visibility:  "public",
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	n.add(m)
+	return n
+}
+
+type mastodon struct {
+	InstanceURL string
+	AccessToken string
+	Visibility  string
+	Mentions    []string
+	Spoiler     string
+}
+
+func (m *mastodon) Send(client *http.Client, message string) error {
+	if len(m.Mentions) > 0 {
+		message = strings.Join(m.Mentions, " ") + "\n" + message
+	}
+	// 多數站台上限 500 字
+	if r := []rune(message); len(r) > 500 {
+		message = string(r[:499]) + "…"
+	}
+
+	status := map[string]interface{}{
+		"status":     message,
+		"visibility": m.Visibility,
+	}
+	if m.Spoiler != "" {
+		status["spoiler_text"] = m.Spoiler
+	}
+
+	return m.SendRaw(client, status)
+}
+
+func (m *mastodon) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["visibility"]; !ok {
+		message["visibility"] = m.Visibility
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", m.InstanceURL+"/api/v1/statuses", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+
+	return request(client, req)
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+type MatrixOption func(*matrix)
+
+// MatrixHTML 訊息視為 HTML，另附去除標籤的純文字 body
+func MatrixHTML() MatrixOption {
+	return func(m *matrix) { m.HTML = true }
+}
+
+// MatrixNotice 以 m.notice 送出，一般 bot 不會回應 notice
+func MatrixNotice() MatrixOption {
+	return func(m *matrix) { m.MsgType = "m.notice" }
+}
+
+func (n *Notify) Matrix(homeserverURL, accessToken, roomID string, opts ...MatrixOption) *Notify {
+	m := &matrix{
+		HomeserverURL: strings.TrimRight(homeserverURL, "/"),
+		AccessToken:   accessToken,
+		RoomID:        roomID,
+		MsgType:       "m.text",
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	n.add(m)
+	return n
+}
+
+type matrix struct {
+	HomeserverURL string
+	AccessToken   string
+	RoomID        string
+	MsgType       string
+	HTML          bool
+
+	txn atomic.Uint64
+}
+
+func (m *matrix) Send(client *http.Client, message string) error {
+	content := map[string]interface{}{
+		"msgtype": m.MsgType,
+		"body":    message,
+	}
+	if m.HTML {
+		content["body"] = html.UnescapeString(htmlTag.ReplaceAllString(message, ""))
+		content["format"] = "org.matrix.custom.html"
+		content["formatted_body"] = message
+	}
+
+	return m.SendRaw(client, content)
+}
+
+func (m *matrix) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["msgtype"]; !ok {
+		message["msgtype"] = m.MsgType
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	txnID := fmt.Sprintf("notify-%d-%d", time.Now().UnixNano(), m.txn.Add(1))
+	url := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		m.HomeserverURL, url.PathEscape(m.RoomID), txnID)
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+
+	return request(client, req)
+}
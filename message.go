@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityError:
+		return "ERROR"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return "INFO"
+	}
+}
+
+// ParseSeverity 解析 info、warn(ing)、err(or)、crit(ical) 等常見寫法，不分大小寫
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "info", "information", "notice":
+		return SeverityInfo, nil
+	case "warn", "warning":
+		return SeverityWarning, nil
+	case "err", "error":
+		return SeverityError, nil
+	case "crit", "critical", "fatal", "emerg", "emergency", "alert":
+		return SeverityCritical, nil
+	}
+	return SeverityInfo, fmt.Errorf("unknown severity %q", s)
+}
+
+// Status 事件狀態，供事件管理平台判斷開啟、確認或關閉事件
+type Status int
+
+const (
+	StatusFiring Status = iota
+	StatusAcknowledged
+	StatusResolved
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusAcknowledged:
+		return "ACKNOWLEDGED"
+	case StatusResolved:
+		return "RESOLVED"
+	default:
+		return "FIRING"
+	}
+}
+
+// ParseStatus 解析 firing、ack(nowledged)、resolved 等寫法，不分大小寫
+func ParseStatus(s string) (Status, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "firing", "problem", "alerting":
+		return StatusFiring, nil
+	case "ack", "acknowledged":
+		return StatusAcknowledged, nil
+	case "resolved", "ok", "recovered":
+		return StatusResolved, nil
+	}
+	return StatusFiring, fmt.Errorf("unknown status %q", s)
+}
+
+// severityColor 各平台 attachment / card 共用的顏色
+func severityColor(message Message) string {
+	if message.Status == StatusResolved {
+		return "#2eb886"
+	}
+	switch message.Severity {
+	case SeverityWarning:
+		return "#daa038"
+	case SeverityError:
+		return "#e8590c"
+	case SeverityCritical:
+		return "#d00000"
+	default:
+		return "#439fe0"
+	}
+}
+
+// severityColorValue severityColor 的數值形式，供 Discord embed 使用
+func severityColorValue(message Message) int {
+	value, _ := strconv.ParseInt(strings.TrimPrefix(severityColor(message), "#"), 16, 32)
+	return int(value)
+}
+
+// truncateRunes 超過 n 個字元時截斷並加上 …
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message 結構化訊息，支援的 notifier 會轉成各平台原生格式，其餘則以 String() 純文字送出
+type Message struct {
+	Title    string
+	Text     string
+	Severity Severity
+	Status   Status
+	Fields   []Field
+	URL      string
+	// Key 標示同一事件，用於訊息串分組或去重
+	Key string
+	// Attachments 支援上傳檔案的 notifier (Telegram、Discord、Webex) 會另外附上，其餘忽略
+	Attachments []Attachment
+	// Actions 支援互動的 notifier (Telegram、Discord、LINE) 會附上按鈕，按下後由 Interactions 接收
+	Actions []Action
+}
+
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+// Action 訊息上的按鈕，ID 會連同 Message.Key 一起回傳給 Interactions
+type Action struct {
+	ID    string
+	Label string
+}
+
+var (
+	AckAction     = Action{ID: "ack", Label: "Ack"}
+	ResolveAction = Action{ID: "resolve", Label: "Resolve"}
+	ApproveAction = Action{ID: "approve", Label: "Approve"}
+	DenyAction    = Action{ID: "deny", Label: "Deny"}
+)
+
+// heading 標題加上狀態前綴，firing 時改為非 INFO 的嚴重度前綴
+func (m Message) heading() string {
+	title := m.Title
+	if m.Status != StatusFiring {
+		title = strings.TrimSpace("[" + m.Status.String() + "] " + title)
+	} else if m.Severity != SeverityInfo {
+		title = strings.TrimSpace("[" + m.Severity.String() + "] " + title)
+	}
+	return title
+}
+
+func (m Message) String() string {
+	var lines []string
+
+	if title := m.heading(); title != "" {
+		lines = append(lines, title)
+	}
+	if m.Text != "" {
+		lines = append(lines, m.Text)
+	}
+	for _, field := range m.Fields {
+		lines = append(lines, field.Name+": "+field.Value)
+	}
+	if m.URL != "" {
+		lines = append(lines, m.URL)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type messageSender interface {
+	SendMessage(*http.Client, Message) error
+}
+
+// messageIDSender 可回傳平台訊息 ID 的 notifier，供 AuditStore 紀錄
+type messageIDSender interface {
+	SendMessageID(*http.Client, Message) (string, error)
+}
+
+func sendMessage(client *http.Client, notifier INotify, message Message) error {
+	if sender, ok := notifier.(messageSender); ok {
+		return sender.SendMessage(client, message)
+	}
+	return notifier.Send(client, message.String())
+}
+
+func sendMessageID(client *http.Client, notifier INotify, message Message) (string, error) {
+	if sender, ok := notifier.(messageIDSender); ok {
+		return sender.SendMessageID(client, message)
+	}
+	return "", sendMessage(client, notifier, message)
+}
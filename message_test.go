@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var nativeTestMessage = Message{
+	Title:    "DB <down>",
+	Text:     "primary unreachable",
+	Severity: SeverityCritical,
+	Fields:   []Field{{Name: "host", Value: "db-1"}},
+	URL:      "https://grafana.example.com/d/1",
+}
+
+// captureJSON 記錄最後一個請求的 JSON body，並以 reply 回應
+func captureJSON(reply string) (*Notify, *map[string]interface{}, func()) {
+	var body map[string]interface{}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(reply))
+	}))
+	return n, &body, done
+}
+
+func TestTelegramSendMessageHTML(t *testing.T) {
+	n, body, done := captureJSON(`{"ok":true,"result":{"message_id":1}}`)
+	defer done()
+
+	if err := n.Telegram("token", "42").Send(nativeTestMessage); err != nil {
+		t.Fatal(err)
+	}
+	want := "<b>[CRITICAL] DB &lt;down&gt;</b>\nprimary unreachable\n<b>host</b>: db-1\n" +
+		`<a href="https://grafana.example.com/d/1">https://grafana.example.com/d/1</a>`
+	if (*body)["text"] != want || (*body)["parse_mode"] != "HTML" {
+		t.Fatalf("body = %v", *body)
+	}
+}
+
+func TestDiscordSendMessageEmbed(t *testing.T) {
+	n, body, done := captureJSON(`{"id":"1"}`)
+	defer done()
+
+	message := nativeTestMessage
+	message.Status = StatusResolved
+	if err := n.Discord("token", "42").Send(message); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"embeds": []interface{}{map[string]interface{}{
+			"title":       "[RESOLVED] DB <down>",
+			"description": "primary unreachable",
+			"url":         "https://grafana.example.com/d/1",
+			"color":       float64(0x2eb886),
+			"fields":      []interface{}{map[string]interface{}{"name": "host", "value": "db-1", "inline": true}},
+		}},
+	}
+	if !reflect.DeepEqual(*body, want) {
+		t.Fatalf("body = %v", *body)
+	}
+}
+
+func TestLineSendMessageFlex(t *testing.T) {
+	n, body, done := captureJSON(`{"sentMessages":[{"id":"1"}]}`)
+	defer done()
+
+	if err := n.Line("token", "U42").Send(nativeTestMessage); err != nil {
+		t.Fatal(err)
+	}
+	messages := (*body)["messages"].([]interface{})
+	flex := messages[0].(map[string]interface{})
+	if flex["type"] != "flex" || flex["altText"] != "[CRITICAL] DB <down>" {
+		t.Fatalf("message = %v", flex)
+	}
+	bubble := flex["contents"].(map[string]interface{})
+	header := bubble["header"].(map[string]interface{})
+	if header["backgroundColor"] != "#d00000" {
+		t.Fatalf("header = %v", header)
+	}
+	for _, part := range []string{"body", "footer"} {
+		if _, ok := bubble[part]; !ok {
+			t.Errorf("bubble has no %s: %v", part, bubble)
+		}
+	}
+}
+
+func TestZulipSendMessageHeading(t *testing.T) {
+	var content []string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		content = append(content, r.PostForm.Get("content"))
+	}))
+	defer done()
+
+	n.Zulip("https://chat.example.com", "bot@example.com", "key", "ops", "alerts")
+	for _, message := range []Message{
+		{Title: "DB down", Severity: SeverityCritical},
+		{Title: "DB down", Severity: SeverityCritical, Status: StatusResolved},
+		{Title: "deployed"},
+	} {
+		if err := n.Send(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"**[CRITICAL] DB down**", "**[RESOLVED] DB down**", "**deployed**"}
+	if !reflect.DeepEqual(content, want) {
+		t.Fatalf("content = %q, want %q", content, want)
+	}
+}
+
+func TestDingTalkSendMessageHeading(t *testing.T) {
+	var body struct {
+		Markdown struct {
+			Title string `json:"title"`
+			Text  string `json:"text"`
+		} `json:"markdown"`
+	}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"errcode":0}`))
+	}))
+	defer done()
+
+	n.DingTalk("token")
+	if err := n.Send(Message{Title: "DB down", Text: "recovered", Severity: SeverityCritical, Status: StatusResolved}); err != nil {
+		t.Fatal(err)
+	}
+	if body.Markdown.Title != "[RESOLVED] DB down" || body.Markdown.Text != "### [RESOLVED] DB down\n\nrecovered" {
+		t.Fatalf("markdown = %+v", body.Markdown)
+	}
+}
+
+func TestWeComSendMessage(t *testing.T) {
+	var bodies []map[string]interface{}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"errcode":0}`))
+	}))
+	defer done()
+
+	n.WeCom("key")
+	messages := []Message{
+		{Text: "disk full", Fields: []Field{{Name: "host", Value: "db-1"}}, URL: "https://grafana.example.com"},
+		{Title: "DB down", Severity: SeverityCritical, Status: StatusResolved},
+		{Title: "DB down", Severity: SeverityCritical},
+	}
+	for _, message := range messages {
+		if err := n.Send(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	article := bodies[0]["news"].(map[string]interface{})["articles"].([]interface{})[0].(map[string]interface{})
+	if article["title"] != "disk full" || article["description"] != "disk full\nhost: db-1" {
+		t.Fatalf("article = %v", article)
+	}
+	for i, want := range []string{
+		`### <font color="info">[RESOLVED] DB down</font>`,
+		`### <font color="warning">[CRITICAL] DB down</font>`,
+	} {
+		if got := bodies[i+1]["markdown"].(map[string]interface{})["content"]; got != want {
+			t.Errorf("content = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestFeishuCardStatus(t *testing.T) {
+	tests := []struct {
+		message  Message
+		template string
+		title    string
+	}{
+		{Message{Title: "DB down", Severity: SeverityCritical}, "red", "[CRITICAL] DB down"},
+		{Message{Title: "DB down", Severity: SeverityCritical, Status: StatusResolved}, "green", "[RESOLVED] DB down"},
+		{Message{Title: "disk", Severity: SeverityWarning, Status: StatusAcknowledged}, "orange", "[ACKNOWLEDGED] disk"},
+		{Message{Text: "deployed v1.2"}, "blue", "deployed v1.2"},
+	}
+	for _, tt := range tests {
+		header := feishuCard(tt.message)["header"].(map[string]interface{})
+		title := header["title"].(map[string]interface{})["content"]
+		if header["template"] != tt.template || title != tt.title {
+			t.Errorf("%+v: template %v, title %v", tt.message, header["template"], title)
+		}
+	}
+}
+
+func TestWebexSendMessageHeading(t *testing.T) {
+	var body map[string]interface{}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer done()
+
+	n.Webex("token", "room-1")
+	if err := n.Send(Message{Title: "DB down", Text: "recovered", Severity: SeverityCritical, Status: StatusResolved}); err != nil {
+		t.Fatal(err)
+	}
+	if body["markdown"] != "**[RESOLVED] DB down**\n\nrecovered" {
+		t.Fatalf("markdown = %q", body["markdown"])
+	}
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (n *Notify) MessageBird(accessKey, originator string, recipients []string) *Notify {
+	n.add(&messagebird{
+		AccessKey:  accessKey,
+		Originator: originator,
+		Recipients: recipients,
+	})
+	return n
+}
+
+type messagebird struct {
+	AccessKey  string
+	Originator string
+	Recipients []string
+}
+
+func (m *messagebird) Send(client *http.Client, message string) error {
+	return m.SendRaw(client, map[string]interface{}{
+		"body":       message,
+		"datacoding": "auto",
+	})
+}
+
+func (m *messagebird) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["originator"]; !ok {
+		message["originator"] = m.Originator
+	}
+	if _, ok := message["recipients"]; !ok {
+		message["recipients"] = m.Recipients
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://rest.messagebird.com/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "AccessKey "+m.AccessKey)
+
+	return request(client, req)
+}
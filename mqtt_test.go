@@ -0,0 +1,233 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMQTTRemainingLength MQTT 3.1.1 規格 2.2.3 的範例
+func TestMQTTRemainingLength(t *testing.T) {
+	tests := []struct {
+		length int
+		want   string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "8001"},
+		{16383, "ff7f"},
+		{16384, "808001"},
+		{2097151, "ffff7f"},
+		{2097152, "80808001"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := mqttWrite(&buf, mqttPublish<<4, make([]byte, tt.length)); err != nil {
+			t.Fatal(err)
+		}
+		packet := buf.Bytes()
+		if got := hex.EncodeToString(packet[1 : 1+len(tt.want)/2]); got != tt.want {
+			t.Errorf("length %d encoded as %s, want %s", tt.length, got, tt.want)
+		}
+
+		packetType, body, err := mqttRead(bufio.NewReader(&buf))
+		if err != nil || packetType != mqttPublish || len(body) != tt.length {
+			t.Errorf("length %d read back as type %d, %d bytes, %v", tt.length, packetType, len(body), err)
+		}
+	}
+}
+
+// fakeMQTTBroker 記錄 client 送出的封包並依 QoS 流程回應
+type fakeMQTTBroker struct {
+	listener   net.Listener
+	returnCode byte
+	done       chan struct{}
+
+	mu      sync.Mutex
+	packets []string
+}
+
+func newFakeMQTTBroker(t *testing.T, returnCode byte) *fakeMQTTBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &fakeMQTTBroker{listener: listener, returnCode: returnCode, done: make(chan struct{})}
+	go func() {
+		defer close(b.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			packet, err := readRawMQTTPacket(reader)
+			if err != nil {
+				return
+			}
+			b.mu.Lock()
+			b.packets = append(b.packets, hex.EncodeToString(packet))
+			b.mu.Unlock()
+
+			switch header := packet[0]; header >> 4 {
+			case mqttConnect:
+				conn.Write([]byte{mqttConnAck << 4, 2, 0, b.returnCode})
+			case mqttPublish:
+				switch (header >> 1) & 0x03 {
+				case 1:
+					conn.Write([]byte{mqttPubAck << 4, 2, 0, 1})
+				case 2:
+					conn.Write([]byte{mqttPubRec << 4, 2, 0, 1})
+				}
+			case mqttPubRel:
+				conn.Write([]byte{mqttPubComp << 4, 2, 0, 1})
+			case mqttDisconnect:
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return b
+}
+
+// readRawMQTTPacket 讀取完整封包 (含 fixed header)，不經過被測的 mqttRead
+func readRawMQTTPacket(r *bufio.Reader) ([]byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	packet := []byte{header}
+	length, multiplier := 0, 1
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		packet = append(packet, b)
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return append(packet, body...), nil
+}
+
+// transcript 等待 client 斷線後回傳收到的封包
+func (b *fakeMQTTBroker) transcript(t *testing.T) []string {
+	select {
+	case <-b.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not disconnect")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.packets...)
+}
+
+func TestMQTTPublish(t *testing.T) {
+	// CONNECT：protocol "MQTT" level 4、flags 0xc2 (username、password、clean session)、keep alive 60、
+	// client id "notify-test"、username "u"、password "p"
+	connect := "101d" + "00044d515454" + "04" + "c2" + "003c" + "000b" + hex.EncodeToString([]byte("notify-test")) + "000175" + "000170"
+	payload := hex.EncodeToString([]byte(`{"text":"hi"}`))
+	topic := "0006" + hex.EncodeToString([]byte("alerts"))
+
+	tests := []struct {
+		name string
+		opts []MQTTOption
+		want []string
+	}{
+		{"qos 0", []MQTTOption{MQTTQoS(0)}, []string{connect, "3015" + topic + payload, "e000"}},
+		{"qos 1 retained", []MQTTOption{MQTTRetained()}, []string{connect, "3317" + topic + "0001" + payload, "e000"}},
+		{"qos 2", []MQTTOption{MQTTQoS(2)}, []string{connect, "3417" + topic + "0001" + payload, "62020001", "e000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := newFakeMQTTBroker(t, 0)
+			opts := append([]MQTTOption{MQTTClientID("notify-test")}, tt.opts...)
+			n := New().MQTT("mqtt://u:p@"+broker.listener.Addr().String(), "alerts", opts...)
+			if err := n.Send(map[string]interface{}{"text": "hi"}); err != nil {
+				t.Fatal(err)
+			}
+
+			got := broker.transcript(t)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("packets =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestMQTTConnectionRefused(t *testing.T) {
+	broker := newFakeMQTTBroker(t, 5)
+	err := New().MQTT("mqtt://"+broker.listener.Addr().String(), "alerts").Send("hi")
+	if err == nil || !strings.Contains(err.Error(), "return code 5") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestMQTTInvalidQoS(t *testing.T) {
+	err := New().MQTT("mqtt://127.0.0.1:1", "alerts", MQTTQoS(3)).Send("hi")
+	if err == nil || !strings.Contains(err.Error(), "invalid MQTT QoS 3") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestMQTTClientIDPerConnection(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	ids := make(chan string, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					packet, err := readRawMQTTPacket(reader)
+					if err != nil {
+						return
+					}
+					switch packet[0] >> 4 {
+					case mqttConnect:
+						// fixed header 2 bytes、protocol name 6、level 1、flags 1、keep alive 2，之後為 client ID
+						size := int(packet[12])<<8 | int(packet[13])
+						ids <- string(packet[14 : 14+size])
+						conn.Write([]byte{mqttConnAck << 4, 2, 0, 0})
+					case mqttDisconnect:
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	n := New().MQTT("mqtt://"+listener.Addr().String(), "alerts", MQTTQoS(0))
+	for i := 0; i < 2; i++ {
+		if err := n.Send("hi"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first, second := <-ids, <-ids
+	if !strings.HasPrefix(first, "notify-") || first == second {
+		t.Fatalf("client IDs %q and %q", first, second)
+	}
+}
@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeNATS 記錄 client 送出的內容，每收到 PING 或 PUB/HPUB 時以 reply 回應
+type fakeNATS struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	transcript strings.Builder
+}
+
+func newFakeNATS(t *testing.T, info string, reply func(conn net.Conn, op, args string)) *fakeNATS {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeNATS{listener: listener}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "INFO %s\r\n", info)
+
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			s.record(line)
+
+			op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+			if op == "PUB" || op == "HPUB" {
+				fields := strings.Fields(args)
+				size, _ := strconv.Atoi(fields[len(fields)-1])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				s.record(string(payload))
+			}
+			reply(conn, op, args)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeNATS) record(data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transcript.WriteString(data)
+}
+
+func (s *fakeNATS) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transcript.String()
+}
+
+func (s *fakeNATS) url(userinfo string) string {
+	return "nats://" + userinfo + s.listener.Addr().String()
+}
+
+func TestNATSPublish(t *testing.T) {
+	server := newFakeNATS(t, `{"headers":true}`, func(conn net.Conn, op, _ string) {
+		if op == "PING" {
+			conn.Write([]byte("PONG\r\n"))
+		}
+	})
+
+	n := New().NATS(server.url("s3cret@"), "alerts")
+	if err := n.Send(map[string]interface{}{"text": "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `CONNECT {"auth_token":"s3cret","headers":true,"lang":"go","name":"notify-go","pedantic":false,"protocol":1,"verbose":false}` + "\r\n" +
+		"PUB alerts 13\r\n" +
+		`{"text":"hi"}` + "\r\n" +
+		"PING\r\n"
+	if got := server.String(); got != want {
+		t.Fatalf("transcript =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestNATSJetStream(t *testing.T) {
+	server := newFakeNATS(t, `{"headers":true}`, func(conn net.Conn, op, args string) {
+		if op == "HPUB" {
+			inbox := strings.Fields(args)[1]
+			ack := `{"stream":"ALERTS","seq":1}`
+			fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", inbox, len(ack), ack)
+		}
+	})
+
+	n := New().NATS(server.url("user:pass@"), "alerts", NATSJetStream())
+	if err := n.Send(map[string]interface{}{"key": "db-1", "text": "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	headers := "NATS/1.0\r\nNats-Msg-Id: db-1\r\n\r\n"
+	payload := `{"key":"db-1","text":"hi"}`
+	want := regexp.MustCompile("^" + regexp.QuoteMeta(`CONNECT {"headers":true,"lang":"go","name":"notify-go","pass":"pass","pedantic":false,"protocol":1,"user":"user","verbose":false}`+"\r\n") +
+		`SUB (_INBOX\.[0-9a-f]{16}) 1\r\n` +
+		`HPUB alerts (_INBOX\.[0-9a-f]{16}) ` + strconv.Itoa(len(headers)) + " " + strconv.Itoa(len(headers)+len(payload)) + `\r\n` +
+		regexp.QuoteMeta(headers+payload+"\r\n") + "$")
+
+	got := server.String()
+	match := want.FindStringSubmatch(got)
+	if match == nil {
+		t.Fatalf("transcript =\n%q", got)
+	}
+	if match[1] != match[2] {
+		t.Fatalf("HPUB reply %s does not match subscription %s", match[2], match[1])
+	}
+}
+
+func TestNATSJetStreamErrors(t *testing.T) {
+	ackError := `{"error":{"code":400,"description":"bad request"}}`
+	tests := []struct {
+		name  string
+		reply func(inbox string) string
+		want  string
+	}{
+		{"no responders", func(inbox string) string {
+			return fmt.Sprintf("HMSG %s 1 16 16\r\nNATS/1.0 503\r\n\r\n\r\n", inbox)
+		}, "JetStream publish failed: 503"},
+		{"ack error", func(inbox string) string {
+			return fmt.Sprintf("MSG %s 1 %d\r\n%s\r\n", inbox, len(ackError), ackError)
+		}, "JetStream publish failed: 400 bad request"},
+		{"server error", func(string) string {
+			return "-ERR 'Permissions Violation'\r\n"
+		}, "nats error: 'Permissions Violation'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeNATS(t, `{}`, func(conn net.Conn, op, args string) {
+				if op == "PUB" {
+					conn.Write([]byte(tt.reply(strings.Fields(args)[1])))
+				}
+			})
+
+			err := New().NATS(server.url(""), "alerts", NATSJetStream()).Send("hi")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
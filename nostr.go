@@ -0,0 +1,327 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type NostrOption func(*nostr)
+
+// NostrDirect 改以 NIP-04 加密私訊 (kind 4) 發送給指定公鑰（hex 或 npub）
+func NostrDirect(pubkeys ...string) NostrOption {
+	return func(n *nostr) {
+		for _, pubkey := range pubkeys {
+			key, err := decodeNostrKey(pubkey, "npub")
+			if err != nil {
+				n.err = err
+				return
+			}
+			n.Recipients = append(n.Recipients, hex.EncodeToString(key))
+		}
+	}
+}
+
+// NostrTags 附加在 kind 1 貼文的 hashtag（t tag）
+func NostrTags(tags ...string) NostrOption {
+	return func(n *nostr) { n.Hashtags = append(n.Hashtags, tags...) }
+}
+
+// Nostr privateKey 為 hex 或 nsec 格式，事件會發布到所有 relay，任一 relay 接受即視為成功
+func (n *Notify) Nostr(privateKey string, relays []string, opts ...NostrOption) *Notify {
+	t := &nostr{Relays: relays}
+	if key, err := decodeNostrKey(privateKey, "nsec"); err != nil {
+		t.err = err
+	} else {
+		t.Secret = new(big.Int).SetBytes(key)
+		if t.Secret.Sign() == 0 || t.Secret.Cmp(secpN) >= 0 {
+			t.err = errors.New("invalid nostr private key")
+		} else {
+			t.PubKey = hex.EncodeToString(bytes32(secpBaseMul(t.Secret).X))
+		}
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	n.add(t)
+	return n
+}
+
+type nostr struct {
+	Secret     *big.Int
+	PubKey     string
+	Relays     []string
+	Recipients []string
+	Hashtags   []string
+
+	err error
+}
+
+type nostrEvent struct {
+	ID        string     `json:"id"`
+	PubKey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+func (t *nostr) Send(client *http.Client, message string) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	if len(t.Recipients) > 0 {
+		var errs []error
+		for _, recipient := range t.Recipients {
+			content, err := t.encrypt(recipient, message)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			errs = append(errs, t.publish(4, [][]string{{"p", recipient}}, content))
+		}
+		return errors.Join(errs...)
+	}
+
+	tags := [][]string{}
+	for _, tag := range t.Hashtags {
+		tags = append(tags, []string{"t", tag})
+	}
+	return t.publish(1, tags, message)
+}
+
+// SendRaw 支援 {"kind": int, "content": string, "tags": [][]string}
+func (t *nostr) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	content, _ := message["content"].(string)
+	kind, ok := message["kind"].(int)
+	if !ok {
+		kind = 1
+	}
+	tags, ok := message["tags"].([][]string)
+	if !ok {
+		tags = [][]string{}
+	}
+
+	return t.publish(kind, tags, content)
+}
+
+func (t *nostr) publish(kind int, tags [][]string, content string) error {
+	event := &nostrEvent{
+		PubKey:    t.PubKey,
+		CreatedAt: time.Now().Unix(),
+		Kind:      kind,
+		Tags:      tags,
+		Content:   content,
+	}
+
+	// NIP-01: id 為 [0, pubkey, created_at, kind, tags, content] 序列化後的 sha256
+	serialized, err := marshalNoEscape([]interface{}{0, event.PubKey, event.CreatedAt, event.Kind, event.Tags, event.Content})
+	if err != nil {
+		return fmt.Errorf("failed to marshal nostr event: %v", err)
+	}
+	id := sha256.Sum256(serialized)
+	event.ID = hex.EncodeToString(id[:])
+
+	aux := make([]byte, 32)
+	rand.Read(aux)
+	sig, err := schnorrSign(t.Secret, id[:], aux)
+	if err != nil {
+		return err
+	}
+	event.Sig = hex.EncodeToString(sig)
+
+	frame, err := marshalNoEscape([]interface{}{"EVENT", event})
+	if err != nil {
+		return fmt.Errorf("failed to marshal nostr event: %v", err)
+	}
+
+	var errs []error
+	for _, relay := range t.Relays {
+		if err := publishNostrEvent(relay, event.ID, frame); err != nil {
+			errs = append(errs, fmt.Errorf("nostr %s: %w", relay, err))
+			continue
+		}
+		// 任一 relay 接受即成功
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
+
+func publishNostrEvent(relay, id string, frame []byte) error {
+	ws, err := dialWebSocket(relay, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	ws.SetDeadline(time.Now().Add(10 * time.Second))
+	if err := ws.WriteText(frame); err != nil {
+		return fmt.Errorf("failed to write event: %v", err)
+	}
+
+	for {
+		data, err := ws.ReadText()
+		if err != nil {
+			return fmt.Errorf("failed to read relay response: %v", err)
+		}
+
+		var response []json.RawMessage
+		if err := json.Unmarshal(data, &response); err != nil || len(response) < 3 {
+			continue
+		}
+
+		var label, eventID string
+		json.Unmarshal(response[0], &label)
+		json.Unmarshal(response[1], &eventID)
+		if label != "OK" || eventID != id {
+			continue
+		}
+
+		var accepted bool
+		var reason string
+		json.Unmarshal(response[2], &accepted)
+		if len(response) > 3 {
+			json.Unmarshal(response[3], &reason)
+		}
+		if !accepted {
+			return errors.New("relay rejected event: " + reason)
+		}
+		return nil
+	}
+}
+
+// encrypt NIP-04：ECDH 共享點的 x 座標作為 AES-256-CBC 金鑰
+func (t *nostr) encrypt(recipient, message string) (string, error) {
+	pubkey, err := hex.DecodeString(recipient)
+	if err != nil {
+		return "", fmt.Errorf("invalid nostr public key: %v", err)
+	}
+	point, err := secpLiftX(new(big.Int).SetBytes(pubkey))
+	if err != nil {
+		return "", err
+	}
+	shared := bytes32(secpMul(point, t.Secret).X)
+
+	block, err := aes.NewCipher(shared)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	padding := aes.BlockSize - len(message)%aes.BlockSize
+	plaintext := append([]byte(message), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", fmt.Errorf("failed to generate iv: %v", err)
+	}
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	return base64.StdEncoding.EncodeToString(ciphertext) + "?iv=" + base64.StdEncoding.EncodeToString(iv), nil
+}
+
+func marshalNoEscape(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// decodeNostrKey 接受 64 字元 hex 或 NIP-19 bech32 (nsec / npub)
+func decodeNostrKey(key, prefix string) ([]byte, error) {
+	if strings.HasPrefix(key, prefix+"1") {
+		hrp, data, err := bech32Decode(key)
+		if err != nil {
+			return nil, err
+		}
+		if hrp != prefix || len(data) != 32 {
+			return nil, fmt.Errorf("invalid %s key", prefix)
+		}
+		return data, nil
+	}
+
+	data, err := hex.DecodeString(key)
+	if err != nil || len(data) != 32 {
+		return nil, fmt.Errorf("invalid nostr key")
+	}
+	return data, nil
+}
+
+func bech32Decode(s string) (string, []byte, error) {
+	const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, errors.New("invalid bech32 string")
+	}
+	hrp := s[:pos]
+
+	var values []byte
+	for _, c := range s[pos+1:] {
+		idx := strings.IndexRune(charset, c)
+		if idx < 0 {
+			return "", nil, errors.New("invalid bech32 character")
+		}
+		values = append(values, byte(idx))
+	}
+
+	// 驗證 checksum
+	var expanded []byte
+	for _, c := range hrp {
+		expanded = append(expanded, byte(c>>5))
+	}
+	expanded = append(expanded, 0)
+	for _, c := range hrp {
+		expanded = append(expanded, byte(c&31))
+	}
+	generator := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range append(expanded, values...) {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	if chk != 1 {
+		return "", nil, errors.New("invalid bech32 checksum")
+	}
+
+	// 5-bit 轉 8-bit
+	var data []byte
+	acc, bits := 0, 0
+	for _, v := range values[:len(values)-6] {
+		acc = acc<<5 | int(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+	}
+
+	return hrp, data, nil
+}
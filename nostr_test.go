@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// TestSchnorrSignBIP340 BIP-340 test-vectors.csv 的簽章範例 0-3
+func TestSchnorrSignBIP340(t *testing.T) {
+	tests := []struct {
+		secret, pubkey, aux, message, sig string
+	}{
+		{
+			secret:  "0000000000000000000000000000000000000000000000000000000000000003",
+			pubkey:  "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			aux:     "0000000000000000000000000000000000000000000000000000000000000000",
+			message: "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:     "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		},
+		{
+			secret:  "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+			pubkey:  "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			aux:     "0000000000000000000000000000000000000000000000000000000000000001",
+			message: "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			sig:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		},
+		{
+			secret:  "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+			pubkey:  "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+			aux:     "C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+			message: "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+			sig:     "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		},
+		{
+			secret:  "0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+			pubkey:  "25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+			aux:     "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+			message: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+			sig:     "7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		},
+	}
+	for i, tt := range tests {
+		secret := new(big.Int).SetBytes(mustHex(t, tt.secret))
+		if got := strings.ToUpper(hex.EncodeToString(bytes32(secpBaseMul(secret).X))); got != tt.pubkey {
+			t.Errorf("vector %d: pubkey = %s, want %s", i, got, tt.pubkey)
+		}
+
+		sig, err := schnorrSign(secret, mustHex(t, tt.message), mustHex(t, tt.aux))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if got := strings.ToUpper(hex.EncodeToString(sig)); got != tt.sig {
+			t.Errorf("vector %d: sig = %s, want %s", i, got, tt.sig)
+		}
+	}
+}
+
+func TestSchnorrSignInvalidKey(t *testing.T) {
+	for _, secret := range []*big.Int{big.NewInt(0), secpN} {
+		if _, err := schnorrSign(secret, make([]byte, 32), make([]byte, 32)); err == nil {
+			t.Errorf("expected error for secret %x", secret)
+		}
+	}
+}
+
+// TestDecodeNostrKeyNIP19 NIP-19 文件中的範例
+func TestDecodeNostrKeyNIP19(t *testing.T) {
+	tests := []struct{ key, prefix, want string }{
+		{"nsec1vl029mgpspedva04g90vltkh6fvh240zqtv9k0t9af8935ke9laqsnlfe5", "nsec", "67dea2ed018072d675f5415ecfaed7d2597555e202d85b3d65ea4e58d2d92ffa"},
+		{"npub10elfcs4fr0l0r8af98jlmgdh9c8tcxjvz9qkw038js35mp4dma8qzvjptg", "npub", "7e7e9c42a91bfef19fa929e5fda1b72e0ebc1a4c1141673e2794234d86addf4e"},
+		{"67dea2ed018072d675f5415ecfaed7d2597555e202d85b3d65ea4e58d2d92ffa", "nsec", "67dea2ed018072d675f5415ecfaed7d2597555e202d85b3d65ea4e58d2d92ffa"},
+	}
+	for _, tt := range tests {
+		key, err := decodeNostrKey(tt.key, tt.prefix)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.key, err)
+		}
+		if got := hex.EncodeToString(key); got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+
+	if _, err := decodeNostrKey("nsec1vl029mgpspedva04g90vltkh6fvh240zqtv9k0t9af8935ke9laqsnlfe6", "nsec"); err == nil {
+		t.Error("expected checksum error")
+	}
+}
+
+// TestNostrEncryptNIP04 收件者以自己的私鑰與寄件者公鑰應能解開
+func TestNostrEncryptNIP04(t *testing.T) {
+	sender := &nostr{Secret: new(big.Int).SetBytes(mustHex(t, "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF"))}
+	recipientSecret := new(big.Int).SetBytes(mustHex(t, "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9"))
+	recipientPub := hex.EncodeToString(bytes32(secpBaseMul(recipientSecret).X))
+
+	content, err := sender.encrypt(recipientPub, "disk full on db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ivData, ok := strings.Cut(content, "?iv=")
+	if !ok {
+		t.Fatalf("content %q has no iv", content)
+	}
+	ciphertext, _ := base64.StdEncoding.DecodeString(data)
+	iv, _ := base64.StdEncoding.DecodeString(ivData)
+
+	senderPoint, err := secpLiftX(secpBaseMul(sender.Secret).X)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(bytes32(secpMul(senderPoint, recipientSecret).X))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	plaintext = plaintext[:len(plaintext)-int(plaintext[len(plaintext)-1])]
+
+	if string(plaintext) != "disk full on db-1" {
+		t.Fatalf("decrypted %q", plaintext)
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 type INotify interface {
@@ -15,11 +20,17 @@ type INotify interface {
 	SendRaw(*http.Client, map[string]interface{}) error
 }
 
+// Notify 可同時由多個 goroutine 呼叫 Send；Notifiers 請透過各 builder 新增，
+// 直接修改 slice 時不受 mutex 保護
 type Notify struct {
 	Client    *http.Client
 	BotToken  string
 	ChatID    string
 	Notifiers []INotify
+
+	mu           sync.RWMutex
+	interactions *Interactions
+	audit        AuditStore
 }
 
 func New() *Notify {
@@ -28,13 +39,30 @@ func New() *Notify {
 	}
 }
 
+func (n *Notify) add(notifier INotify) *Notify {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.Notifiers = append(n.Notifiers, notifier)
+	return n
+}
+
+// notifiers 目前註冊的 notifier 快照，送出期間新增的通道不影響這次 Send
+func (n *Notify) notifiers() []INotify {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return append([]INotify(nil), n.Notifiers...)
+}
+
 func (n *Notify) Send(message interface{}) error {
 	var errs []error
+	notifiers := n.notifiers()
 
 	switch msg := message.(type) {
 	case string:
-		for _, notify := range n.Notifiers {
-			if err := notify.Send(n.Client, msg); err != nil {
+		for _, notify := range notifiers {
+			err := notify.Send(n.Client, msg)
+			n.record(notify, Message{Text: msg}, "", err)
+			if err != nil {
 				log.Println("notify send error", err)
 				errs = append(errs, err)
 			}
@@ -42,8 +70,10 @@ func (n *Notify) Send(message interface{}) error {
 
 	case []string:
 		newMessage := strings.Join(msg, "\n")
-		for _, notify := range n.Notifiers {
-			if err := notify.Send(n.Client, newMessage); err != nil {
+		for _, notify := range notifiers {
+			err := notify.Send(n.Client, newMessage)
+			n.record(notify, Message{Text: newMessage}, "", err)
+			if err != nil {
 				log.Println("notify send error", err)
 				errs = append(errs, err)
 			}
@@ -51,8 +81,39 @@ func (n *Notify) Send(message interface{}) error {
 
 	case map[string]interface{}:
 		// 處理 Raw message
-		for _, notify := range n.Notifiers {
-			if err := notify.SendRaw(n.Client, msg); err != nil {
+		var raw []byte
+		if n.auditStore() != nil {
+			raw, _ = json.Marshal(msg)
+		}
+		for _, notify := range notifiers {
+			// 部分 notifier 會補上預設欄位，每個通道使用各自的副本避免互相影響
+			payload := make(map[string]interface{}, len(msg))
+			for key, value := range msg {
+				payload[key] = value
+			}
+			err := notify.SendRaw(n.Client, payload)
+			n.record(notify, Message{Text: string(raw)}, "", err)
+			if err != nil {
+				log.Println("notify send error", err)
+				errs = append(errs, err)
+			}
+		}
+
+	case Message:
+		for _, notify := range notifiers {
+			id, err := sendMessageID(n.Client, notify, msg)
+			n.record(notify, msg, id, err)
+			if err != nil {
+				log.Println("notify send error", err)
+				errs = append(errs, err)
+			}
+		}
+
+	case *Message:
+		for _, notify := range notifiers {
+			id, err := sendMessageID(n.Client, notify, *msg)
+			n.record(notify, *msg, id, err)
+			if err != nil {
 				log.Println("notify send error", err)
 				errs = append(errs, err)
 			}
@@ -68,6 +129,42 @@ func (n *Notify) Send(message interface{}) error {
 	return nil
 }
 
+// APIError 平台回應非 2xx 狀態，可用 errors.As 取得狀態碼與回應內容
+type APIError struct {
+	Method string
+	Host   string
+	// Path 已遮蔽 Telegram bot token，但 webhook 類 URL 的路徑仍可能含有金鑰，因此不列入 Error()
+	Path       string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body 回應內容，最多保留 4KB
+	Body string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s API responded with status: %v", e.Method, e.Host, e.Status)
+	if body := strings.TrimSpace(e.Body); body != "" {
+		msg += ": " + body
+	}
+	return msg
+}
+
+var botTokenPath = regexp.MustCompile(`/bot[^/]+`)
+
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &APIError{
+		Method:     req.Method,
+		Host:       req.URL.Host,
+		Path:       botTokenPath.ReplaceAllString(req.URL.Path, "/bot***"),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       string(body),
+	}
+}
+
 func request(client *http.Client, req *http.Request) error {
 	resp, err := client.Do(req)
 	if err != nil {
@@ -75,15 +172,42 @@ func request(client *http.Client, req *http.Request) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(req.Host, " API responded with status: %v", resp.Status)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(req, resp)
+	}
+
+	return nil
+}
+
+func requestJSON(client *http.Client, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(req, resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
 	}
 
 	return nil
 }
 
+// subjectOf 取訊息第一行作為郵件主旨
+func subjectOf(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	if r := []rune(subject); len(r) > 78 {
+		subject = string(r[:78])
+	}
+	return subject
+}
+
 func (n *Notify) Telegram(botToken, chatId string) *Notify {
-	n.Notifiers = append(n.Notifiers, &telegram{
+	n.add(&telegram{
 		BotToken: botToken,
 		ChatID:   chatId,
 	})
@@ -91,16 +215,17 @@ func (n *Notify) Telegram(botToken, chatId string) *Notify {
 }
 
 type telegram struct {
-	BotToken string `json:"-"`
-	ChatID   string `json:"chat_id"`
-	Text     string `json:"text"`
+	BotToken string
+	ChatID   string
 }
 
 func (t *telegram) Send(client *http.Client, message string) error {
 	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.BotToken)
-	t.Text = message
 
-	jsonData, err := json.Marshal(t)
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"chat_id": t.ChatID,
+		"text":    message,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal json: %v", err)
 	}
@@ -135,8 +260,77 @@ func (t *telegram) SendRaw(client *http.Client, message map[string]interface{})
 	return request(client, req)
 }
 
+// SendMessage 以 HTML parse mode 呈現，Actions 以 inline keyboard 按鈕附上，附件再以 sendDocument 逐一上傳
+func (t *telegram) SendMessage(client *http.Client, message Message) error {
+	_, err := t.SendMessageID(client, message)
+	return err
+}
+
+// SendMessageID 回傳文字訊息的 message_id
+func (t *telegram) SendMessageID(client *http.Client, message Message) (string, error) {
+	payload := map[string]interface{}{"chat_id": t.ChatID, "text": telegramHTML(message), "parse_mode": "HTML"}
+	if len(message.Actions) > 0 {
+		var buttons []map[string]interface{}
+		for _, action := range message.Actions {
+			buttons = append(buttons, map[string]interface{}{
+				"text":          action.Label,
+				"callback_data": actionData(action.ID, message.Key),
+			})
+		}
+		payload["reply_markup"] = map[string]interface{}{"inline_keyboard": [][]map[string]interface{}{buttons}}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal json: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.BotToken), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		Result struct {
+			MessageID int64 `json:"message_id"`
+		} `json:"result"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+	id := strconv.FormatInt(result.Result.MessageID, 10)
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendDocument", t.BotToken)
+	for _, attachment := range message.Attachments {
+		err := postMultipart(client, url, map[string]string{"chat_id": t.ChatID}, "document", attachment, nil)
+		if err != nil {
+			return id, err
+		}
+	}
+	return id, nil
+}
+
+// telegramHTML 標題粗體、Fields 以 "名稱: 值" 逐行列出，其餘內容需跳脫
+func telegramHTML(message Message) string {
+	var lines []string
+	if title := message.heading(); title != "" {
+		lines = append(lines, "<b>"+html.EscapeString(title)+"</b>")
+	}
+	if message.Text != "" {
+		lines = append(lines, html.EscapeString(message.Text))
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, "<b>"+html.EscapeString(field.Name)+"</b>: "+html.EscapeString(field.Value))
+	}
+	if message.URL != "" {
+		lines = append(lines, fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(message.URL), html.EscapeString(message.URL)))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (n *Notify) Line(botToken, chatId string) *Notify {
-	n.Notifiers = append(n.Notifiers, &line{
+	n.add(&line{
 		BotToken: botToken,
 		ChatID:   chatId,
 	})
@@ -144,18 +338,22 @@ func (n *Notify) Line(botToken, chatId string) *Notify {
 }
 
 type line struct {
-	BotToken string        `json:"-"`
-	ChatID   string        `json:"to"`
-	Messages []interface{} `json:"messages"`
+	BotToken string
+	ChatID   string
 }
 
 func (l *line) Send(client *http.Client, message string) error {
-	l.Messages = append(l.Messages, map[string]interface{}{
+	return l.SendRaw(client, map[string]interface{}{
 		"type": "text",
 		"text": message,
 	})
+}
 
-	jsonData, err := json.Marshal(l)
+func (l *line) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"to":       l.ChatID,
+		"messages": []interface{}{message},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %v", err)
 	}
@@ -170,27 +368,126 @@ func (l *line) Send(client *http.Client, message string) error {
 
 	return request(client, req)
 }
-func (l *line) SendRaw(client *http.Client, message map[string]interface{}) error {
-	l.Messages = append(l.Messages, message)
 
-	jsonData, err := json.Marshal(l)
+// SendMessage 以 flex message 呈現，Actions 以 postback 按鈕範本附上
+func (l *line) SendMessage(client *http.Client, message Message) error {
+	_, err := l.SendMessageID(client, message)
+	return err
+}
+
+// SendMessageID 回傳 push 回應的訊息 ID，多則時以逗號分隔
+func (l *line) SendMessageID(client *http.Client, message Message) (string, error) {
+	messages := []interface{}{lineFlex(message)}
+	if len(message.Actions) > 0 {
+		// 按鈕範本的文字上限 160 字，完整內容另以文字訊息送出
+		text := message.Title
+		if text == "" {
+			text = subjectOf(message.Text)
+		}
+		if r := []rune(text); len(r) > 160 {
+			text = string(r[:159]) + "…"
+		}
+		var actions []map[string]interface{}
+		for _, action := range message.Actions {
+			actions = append(actions, map[string]interface{}{
+				"type":  "postback",
+				"label": action.Label,
+				"data":  actionData(action.ID, message.Key),
+			})
+		}
+		messages = append(messages, map[string]interface{}{
+			"type":     "template",
+			"altText":  text,
+			"template": map[string]interface{}{"type": "buttons", "text": text, "actions": actions},
+		})
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{"to": l.ChatID, "messages": messages})
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %v", err)
+		return "", fmt.Errorf("failed to marshal JSON: %v", err)
 	}
 
 	req, err := http.NewRequest("POST", "https://api.line.me/v2/bot/message/push", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		return "", fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+l.BotToken)
 
-	return request(client, req)
+	var result struct {
+		SentMessages []struct {
+			ID string `json:"id"`
+		} `json:"sentMessages"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, sent := range result.SentMessages {
+		ids = append(ids, sent.ID)
+	}
+	return strings.Join(ids, ","), nil
+}
+
+// lineFlex 標題放在依 Severity / Status 著色的 header，Fields 以兩欄排列，URL 作為 footer 按鈕
+func lineFlex(message Message) map[string]interface{} {
+	bubble := map[string]interface{}{"type": "bubble"}
+	title := message.heading()
+	if title != "" {
+		bubble["header"] = map[string]interface{}{
+			"type":            "box",
+			"layout":          "vertical",
+			"backgroundColor": severityColor(message),
+			"contents": []interface{}{
+				map[string]interface{}{"type": "text", "text": title, "weight": "bold", "color": "#ffffff", "wrap": true},
+			},
+		}
+	}
+
+	var body []interface{}
+	if message.Text != "" {
+		body = append(body, map[string]interface{}{"type": "text", "text": message.Text, "wrap": true})
+	}
+	for _, field := range message.Fields {
+		if field.Name == "" || field.Value == "" {
+			continue
+		}
+		body = append(body, map[string]interface{}{
+			"type":   "box",
+			"layout": "baseline",
+			"contents": []interface{}{
+				map[string]interface{}{"type": "text", "text": field.Name, "color": "#aaaaaa", "size": "sm", "flex": 2, "wrap": true},
+				map[string]interface{}{"type": "text", "text": field.Value, "size": "sm", "flex": 5, "wrap": true},
+			},
+		})
+	}
+	if len(body) > 0 {
+		bubble["body"] = map[string]interface{}{"type": "box", "layout": "vertical", "spacing": "sm", "contents": body}
+	}
+	if message.URL != "" {
+		bubble["footer"] = map[string]interface{}{
+			"type":   "box",
+			"layout": "vertical",
+			"contents": []interface{}{
+				map[string]interface{}{"type": "button", "style": "link", "action": map[string]interface{}{"type": "uri", "label": "Open", "uri": message.URL}},
+			},
+		}
+	}
+	if title == "" && len(body) == 0 {
+		// flex message 不可為空
+		return map[string]interface{}{"type": "text", "text": message.String()}
+	}
+
+	altText := title
+	if altText == "" {
+		altText = subjectOf(message.Text)
+	}
+	return map[string]interface{}{"type": "flex", "altText": truncateRunes(altText, 400), "contents": bubble}
 }
 
 func (n *Notify) Discord(botToken, channelID string) *Notify {
-	n.Notifiers = append(n.Notifiers, &discord{
+	n.add(&discord{
 		BotToken: botToken,
 		ChatID:   channelID,
 	})
@@ -198,15 +495,12 @@ func (n *Notify) Discord(botToken, channelID string) *Notify {
 }
 
 type discord struct {
-	BotToken string `json:"-"`
-	ChatID   string `json:"-"`
-	Content  string `json:"content"`
+	BotToken string
+	ChatID   string
 }
 
 func (d *discord) Send(client *http.Client, message string) error {
-	d.Content = message
-
-	jsonData, err := json.Marshal(d)
+	jsonData, err := json.Marshal(map[string]interface{}{"content": message})
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %v", err)
 	}
@@ -241,3 +535,87 @@ func (d *discord) SendRaw(client *http.Client, message map[string]interface{}) e
 
 	return request(client, req)
 }
+
+// SendMessage 以 embed 呈現，Actions 以按鈕元件附上，附件再以 multipart 逐一上傳
+func (d *discord) SendMessage(client *http.Client, message Message) error {
+	_, err := d.SendMessageID(client, message)
+	return err
+}
+
+// SendMessageID 回傳文字訊息的 message ID
+func (d *discord) SendMessageID(client *http.Client, message Message) (string, error) {
+	payload := map[string]interface{}{"embeds": []interface{}{discordEmbed(message)}}
+	if len(message.Actions) > 0 {
+		var buttons []map[string]interface{}
+		for _, action := range message.Actions {
+			buttons = append(buttons, map[string]interface{}{
+				"type":      2,
+				"style":     1,
+				"label":     action.Label,
+				"custom_id": actionData(action.ID, message.Key),
+			})
+		}
+		payload["components"] = []map[string]interface{}{{"type": 1, "components": buttons}}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/channels/%s/messages", d.ChatID)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bot "+d.BotToken)
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+
+	header := http.Header{"Authorization": {"Bot " + d.BotToken}}
+	for _, attachment := range message.Attachments {
+		if err := postMultipart(client, url, nil, "files[0]", attachment, header); err != nil {
+			return result.ID, err
+		}
+	}
+	return result.ID, nil
+}
+
+// discordEmbed 顏色依 Severity / Status，Fields 以 inline 欄位並排；長度依 Discord 的 embed 上限截斷
+func discordEmbed(message Message) map[string]interface{} {
+	embed := map[string]interface{}{"color": severityColorValue(message)}
+	if title := message.heading(); title != "" {
+		embed["title"] = truncateRunes(title, 256)
+	}
+	if message.Text != "" {
+		embed["description"] = truncateRunes(message.Text, 4096)
+	}
+	if message.URL != "" {
+		embed["url"] = message.URL
+	}
+	var fields []map[string]interface{}
+	for _, field := range message.Fields {
+		if field.Name == "" || field.Value == "" {
+			continue
+		}
+		if len(fields) == 25 {
+			break
+		}
+		fields = append(fields, map[string]interface{}{
+			"name":   truncateRunes(field.Name, 256),
+			"value":  truncateRunes(field.Value, 1024),
+			"inline": true,
+		})
+	}
+	if len(fields) > 0 {
+		embed["fields"] = fields
+	}
+	return embed
+}
@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+)
+
+// rewriteTransport 將所有請求改送到測試 server，保留原本的 path 與 query
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// testNotify 建立所有請求都送往 handler 的 Notify
+func testNotify(handler http.Handler) (*Notify, func()) {
+	srv := httptest.NewServer(handler)
+	target, _ := url.Parse(srv.URL)
+	n := New()
+	n.Client = &http.Client{Transport: rewriteTransport{target: target}}
+	return n, srv.Close
+}
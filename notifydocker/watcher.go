@@ -0,0 +1,336 @@
+// Package notifydocker 監看 Docker engine event，容器異常結束、OOM 或 healthcheck 失敗時發出通知
+package notifydocker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+type Option func(*Watcher)
+
+// WithHost Docker daemon 位址，例如 "unix:///var/run/docker.sock" 或 "tcp://10.0.0.5:2375"，預設讀取 $DOCKER_HOST
+func WithHost(host string) Option {
+	return func(w *Watcher) { w.host = host }
+}
+
+// WithLabel 只監看帶有指定 label 的容器，例如 "notify=true" 或 "com.docker.compose.project=game"
+func WithLabel(label string) Option {
+	return func(w *Watcher) { w.labels = append(w.labels, label) }
+}
+
+// IgnoreExitCodes 不通知的結束碼，預設只忽略 0；docker stop/kill 造成的結束一律不通知
+func IgnoreExitCodes(codes ...int) Option {
+	return func(w *Watcher) {
+		for _, code := range codes {
+			w.ignore[code] = true
+		}
+	}
+}
+
+// WithThrottle 相同容器與事件在 window 內只送一次，且每個 window 最多送 burst 則，預設 5 分鐘 20 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(w *Watcher) { w.throttle = notify.NewThrottle(window, burst) }
+}
+
+type Watcher struct {
+	notify   *notify.Notify
+	host     string
+	labels   []string
+	ignore   map[int]bool
+	throttle *notify.Throttle
+	hostname string
+
+	client *http.Client
+	base   string
+
+	mu        sync.Mutex
+	killed    map[string]time.Time
+	unhealthy map[string]bool
+}
+
+func New(n *notify.Notify, opts ...Option) *Watcher {
+	w := &Watcher{
+		notify:    n,
+		host:      os.Getenv("DOCKER_HOST"),
+		ignore:    map[int]bool{0: true},
+		throttle:  notify.NewThrottle(5*time.Minute, 20),
+		killed:    map[string]time.Time{},
+		unhealthy: map[string]bool{},
+	}
+	w.hostname, _ = os.Hostname()
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// event Docker engine API 的 /events 回應
+type event struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	Time     int64 `json:"time"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+// Run 持續監看直到 ctx 結束，連線中斷時自動重連並從中斷處補上事件
+func (w *Watcher) Run(ctx context.Context) error {
+	if err := w.connect(); err != nil {
+		return err
+	}
+
+	since := time.Now()
+	for {
+		last, err := w.stream(ctx, since)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !last.IsZero() {
+			since = last
+		}
+		if err != nil {
+			log.Println("notifydocker:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (w *Watcher) connect() error {
+	host := w.host
+	if host == "" {
+		host = "unix:///var/run/docker.sock"
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("invalid docker host %q: %v", host, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		w.base = "http://docker"
+		w.client = &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}}
+	case "tcp", "http":
+		w.base = "http://" + u.Host
+		w.client = &http.Client{}
+	default:
+		return fmt.Errorf("unsupported docker host scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+func (w *Watcher) stream(ctx context.Context, since time.Time) (time.Time, error) {
+	filters := map[string][]string{
+		"type":  {"container"},
+		"event": {"die", "oom", "kill", "health_status"},
+	}
+	if len(w.labels) > 0 {
+		filters["label"] = w.labels
+	}
+	filterJSON, _ := json.Marshal(filters)
+
+	query := url.Values{}
+	query.Set("filters", string(filterJSON))
+	query.Set("since", strconv.FormatInt(since.Unix(), 10))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", w.base+"/events?"+query.Encode(), nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("docker events responded with status %d", resp.StatusCode)
+	}
+
+	var last time.Time
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		at := time.Unix(0, e.TimeNano)
+		// 重連時 since 只到秒，跳過已處理過的事件
+		if !at.After(since) && e.TimeNano != 0 {
+			continue
+		}
+		last = at
+		w.handle(ctx, e)
+	}
+	return last, scanner.Err()
+}
+
+func (w *Watcher) handle(ctx context.Context, e event) {
+	id := e.Actor.ID
+	attributes := e.Actor.Attributes
+	name := attributes["name"]
+
+	message := notify.Message{
+		Fields: []notify.Field{
+			{Name: "container", Value: name},
+			{Name: "image", Value: attributes["image"]},
+			{Name: "host", Value: w.hostname},
+		},
+	}
+
+	switch {
+	case e.Action == "kill":
+		// docker stop/kill 之後的 die 屬於預期的結束
+		w.mu.Lock()
+		w.killed[id] = time.Now()
+		w.mu.Unlock()
+		return
+
+	case e.Action == "die":
+		code, _ := strconv.Atoi(attributes["exitCode"])
+		w.mu.Lock()
+		killedAt, killed := w.killed[id]
+		delete(w.killed, id)
+		delete(w.unhealthy, id)
+		w.mu.Unlock()
+		if w.ignore[code] || (killed && time.Since(killedAt) < time.Minute) {
+			return
+		}
+
+		message.Title = "Container exited: " + name
+		message.Text = exitReason(code)
+		message.Severity = notify.SeverityError
+		message.Fields = append(message.Fields, notify.Field{Name: "exit code", Value: strconv.Itoa(code)})
+
+	case e.Action == "oom":
+		message.Title = "Container OOM: " + name
+		message.Text = "The container exceeded its memory limit and a process was killed by the kernel."
+		message.Severity = notify.SeverityError
+
+	case strings.HasPrefix(e.Action, "health_status"):
+		status := strings.TrimSpace(strings.TrimPrefix(e.Action, "health_status:"))
+		w.mu.Lock()
+		wasUnhealthy := w.unhealthy[id]
+		if status == "unhealthy" {
+			w.unhealthy[id] = true
+		} else {
+			delete(w.unhealthy, id)
+		}
+		w.mu.Unlock()
+
+		switch {
+		case status == "unhealthy":
+			message.Title = "Container unhealthy: " + name
+			message.Text = w.lastHealthOutput(ctx, id)
+			message.Severity = notify.SeverityWarning
+		case status == "healthy" && wasUnhealthy:
+			message.Title = "Container healthy: " + name
+			message.Severity = notify.SeverityInfo
+			message.Status = notify.StatusResolved
+		default:
+			return
+		}
+		// 復原通知與 unhealthy 共用 Key，讓支援的通道可以關閉原本的告警
+		message.Key = "docker/" + name + "/health"
+
+	default:
+		return
+	}
+
+	if message.Key == "" {
+		message.Key = "docker/" + name + "/" + e.Action
+	}
+	if message.Status != notify.StatusResolved && !w.throttle.Allow(message.Key) {
+		return
+	}
+	w.notify.Send(message)
+}
+
+// lastHealthOutput 取得最近一次 healthcheck 的輸出
+func (w *Watcher) lastHealthOutput(ctx context.Context, id string) string {
+	req, err := http.NewRequestWithContext(ctx, "GET", w.base+"/containers/"+id+"/json", nil)
+	if err != nil {
+		return ""
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	var container struct {
+		State struct {
+			Health struct {
+				FailingStreak int `json:"FailingStreak"`
+				Log           []struct {
+					ExitCode int    `json:"ExitCode"`
+					Output   string `json:"Output"`
+				} `json:"Log"`
+			} `json:"Health"`
+		} `json:"State"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&container); err != nil {
+		return ""
+	}
+
+	health := container.State.Health
+	if len(health.Log) == 0 {
+		return ""
+	}
+	output := strings.TrimSpace(health.Log[len(health.Log)-1].Output)
+	return fmt.Sprintf("%d consecutive failed checks\n%s", health.FailingStreak, output)
+}
+
+func exitReason(code int) string {
+	switch code {
+	case 1:
+		return "Application error"
+	case 125:
+		return "The container failed to run (docker run error)"
+	case 126:
+		return "Command could not be invoked"
+	case 127:
+		return "Command not found"
+	case 134:
+		return "Aborted (SIGABRT)"
+	case 137:
+		return "Killed (SIGKILL), possibly out of memory"
+	case 139:
+		return "Segmentation fault (SIGSEGV)"
+	case 143:
+		return "Terminated (SIGTERM)"
+	}
+	if code > 128 {
+		return fmt.Sprintf("Killed by signal %d", code-128)
+	}
+	return fmt.Sprintf("Exited with code %d", code)
+}
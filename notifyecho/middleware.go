@@ -0,0 +1,128 @@
+// Package notifyecho Echo 的 recover 與 5xx 通知 middleware
+package notifyecho
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+	"github.com/labstack/echo/v4"
+)
+
+// panicKey Recover 已通知過的請求，Errors 不再重複送出
+const panicKey = "notifyecho.panic"
+
+type Option func(*middleware)
+
+// WithThrottle 相同路由與狀態在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(m *middleware) { m.throttle = notify.NewThrottle(window, burst) }
+}
+
+type middleware struct {
+	notify   *notify.Notify
+	throttle *notify.Throttle
+}
+
+func newMiddleware(n *notify.Notify, opts []Option) *middleware {
+	m := &middleware{
+		notify:   n,
+		throttle: notify.NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Recover 取代 middleware.Recover()，panic 時通知 stack trace 與請求資訊，並交由 HTTPErrorHandler 回應 500
+func Recover(n *notify.Notify, opts ...Option) echo.MiddlewareFunc {
+	m := newMiddleware(n, opts)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			start := time.Now()
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				message := notify.PanicMessage(c.Request(), recovered, debug.Stack())
+				message.Fields = contextFields(c, start)
+				m.send(message)
+
+				c.Set(panicKey, true)
+				err = echo.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("panic: %v", recovered))
+			}()
+			return next(c)
+		}
+	}
+}
+
+// Errors handler 回傳 5xx 錯誤 (或直接寫出 5xx 狀態) 時通知；需放在 Recover 之前 (外層)
+func Errors(n *notify.Notify, opts ...Option) echo.MiddlewareFunc {
+	m := newMiddleware(n, opts)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if panicked, _ := c.Get(panicKey).(bool); panicked {
+				return err
+			}
+
+			// 錯誤尚未經過 HTTPErrorHandler，狀態碼要從 error 判斷
+			status := c.Response().Status
+			text := ""
+			if err != nil {
+				status = http.StatusInternalServerError
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				}
+				text = err.Error()
+			}
+			if status < http.StatusInternalServerError {
+				return err
+			}
+
+			m.send(notify.Message{
+				Title:    fmt.Sprintf("%d %s %s", status, c.Request().Method, route(c)),
+				Text:     text,
+				Severity: notify.SeverityError,
+				Fields:   append(contextFields(c, start), notify.Field{Name: "status", Value: strconv.Itoa(status)}),
+				Key:      fmt.Sprintf("http/%d %s %s", status, c.Request().Method, route(c)),
+			})
+			return err
+		}
+	}
+}
+
+func (m *middleware) send(message notify.Message) {
+	if m.throttle.Allow(message.Key + "\x00" + message.Title) {
+		m.notify.Send(message)
+	}
+}
+
+func route(c echo.Context) string {
+	if path := c.Path(); path != "" {
+		return path
+	}
+	return c.Request().URL.Path
+}
+
+func contextFields(c echo.Context, start time.Time) []notify.Field {
+	return []notify.Field{
+		{Name: "route", Value: route(c)},
+		{Name: "method", Value: c.Request().Method},
+		{Name: "path", Value: c.Request().URL.Path},
+		{Name: "client", Value: c.RealIP()},
+		{Name: "latency", Value: time.Since(start).Round(time.Millisecond).String()},
+	}
+}
@@ -0,0 +1,109 @@
+// Package notifygin Gin 的 recovery 與 5xx 通知 middleware
+package notifygin
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	notify "github.com/gps-gaming/notify-go"
+)
+
+// panicKey Recovery 已通知過的請求，Errors 不再重複送出
+const panicKey = "notifygin.panic"
+
+type Option func(*middleware)
+
+// WithThrottle 相同路由與狀態在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(m *middleware) { m.throttle = notify.NewThrottle(window, burst) }
+}
+
+type middleware struct {
+	notify   *notify.Notify
+	throttle *notify.Throttle
+}
+
+func newMiddleware(n *notify.Notify, opts []Option) *middleware {
+	m := &middleware{
+		notify:   n,
+		throttle: notify.NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Recovery 取代 gin.Recovery()，panic 時通知 stack trace 與請求資訊並回應 500
+func Recovery(n *notify.Notify, opts ...Option) gin.HandlerFunc {
+	m := newMiddleware(n, opts)
+	return func(c *gin.Context) {
+		start := time.Now()
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			message := notify.PanicMessage(c.Request, recovered, debug.Stack())
+			message.Fields = contextFields(c, start)
+			m.send(message)
+
+			c.Set(panicKey, true)
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}()
+		c.Next()
+	}
+}
+
+// Errors 請求結束後狀態碼為 5xx 時通知，附上 c.Errors；需放在 Recovery 之前 (外層)
+func Errors(n *notify.Notify, opts ...Option) gin.HandlerFunc {
+	m := newMiddleware(n, opts)
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError || c.GetBool(panicKey) {
+			return
+		}
+
+		m.send(notify.Message{
+			Title:    fmt.Sprintf("%d %s %s", status, c.Request.Method, route(c)),
+			Text:     c.Errors.String(),
+			Severity: notify.SeverityError,
+			Fields:   append(contextFields(c, start), notify.Field{Name: "status", Value: strconv.Itoa(status)}),
+			Key:      fmt.Sprintf("http/%d %s %s", status, c.Request.Method, route(c)),
+		})
+	}
+}
+
+func (m *middleware) send(message notify.Message) {
+	if m.throttle.Allow(message.Key + "\x00" + message.Title) {
+		m.notify.Send(message)
+	}
+}
+
+func route(c *gin.Context) string {
+	if path := c.FullPath(); path != "" {
+		return path
+	}
+	return c.Request.URL.Path
+}
+
+func contextFields(c *gin.Context, start time.Time) []notify.Field {
+	return []notify.Field{
+		{Name: "route", Value: route(c)},
+		{Name: "method", Value: c.Request.Method},
+		{Name: "path", Value: c.Request.URL.Path},
+		{Name: "client", Value: c.ClientIP()},
+		{Name: "latency", Value: time.Since(start).Round(time.Millisecond).String()},
+	}
+}
@@ -0,0 +1,326 @@
+// Package notifygrpc 以 gRPC 提供 notify.v1.NotifyService (定義於 notify.proto)，
+// 讓內部微服務共用集中設定的通道，不必各自保存各平台的憑證
+package notifygrpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	notify "github.com/gps-gaming/notify-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Option func(*Server)
+
+// WithToken 要求 metadata authorization: Bearer <token>；
+// 未設定 token 時所有呼叫一律拒絕，除非明確使用 AllowUnauthenticated
+func WithToken(token string) Option {
+	return func(s *Server) { s.token = token }
+}
+
+// AllowUnauthenticated 未設定 token 時仍接受呼叫，僅適用於已由 mTLS 或網路隔離限制存取的環境
+func AllowUnauthenticated() Option {
+	return func(s *Server) { s.allowUnauthenticated = true }
+}
+
+// WithDefaultTargets 請求未指定 targets 時使用，預設為全部
+func WithDefaultTargets(targets ...string) Option {
+	return func(s *Server) { s.defaults = targets }
+}
+
+type Server struct {
+	targets              map[string]*notify.Notify
+	token                string
+	allowUnauthenticated bool
+	defaults             []string
+}
+
+func New(targets map[string]*notify.Notify, opts ...Option) *Server {
+	s := &Server{targets: targets}
+	for name := range targets {
+		s.defaults = append(s.defaults, name)
+	}
+	sort.Strings(s.defaults)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register 註冊到 grpc.Server，建立 server 時需加上 ServerCodec()，例如
+//
+//	gs := grpc.NewServer(notifygrpc.ServerCodec())
+//	notifygrpc.Register(gs, notifygrpc.New(targets, notifygrpc.WithToken(token)))
+func Register(gs *grpc.Server, srv *Server) {
+	gs.RegisterService(&ServiceDesc, srv)
+}
+
+func (s *Server) Send(ctx context.Context, req *SendRequest) (*SendResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	fields := make([]notify.Field, 0, len(req.Fields))
+	for name, value := range req.Fields {
+		fields = append(fields, notify.Field{Name: name, Value: value})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	return s.send(notify.Message{
+		Title:    req.Title,
+		Text:     req.Text,
+		Severity: notify.Severity(req.Severity),
+		Status:   notify.Status(req.Status),
+		Fields:   fields,
+		URL:      req.URL,
+		Key:      req.Key,
+	}, req.Targets)
+}
+
+func (s *Server) SendTemplate(ctx context.Context, req *SendTemplateRequest) (*SendResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(req.Template)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template: %v", err)
+	}
+	var text strings.Builder
+	if err := tmpl.Execute(&text, req.Data); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to render template: %v", err)
+	}
+
+	return s.send(notify.Message{
+		Title:    req.Title,
+		Text:     text.String(),
+		Severity: notify.Severity(req.Severity),
+		Status:   notify.Status(req.Status),
+		Key:      req.Key,
+	}, req.Targets)
+}
+
+// SendStream 每收到一則請求即送出並回傳結果，單則失敗不會中斷 stream
+func (s *Server) SendStream(stream NotifyService_SendStreamServer) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.Send(stream.Context(), req)
+		if err != nil {
+			// 參數錯誤以結果回報，讓後續訊息可以繼續送
+			resp = &SendResponse{Errors: map[string]string{"": status.Convert(err).Message()}}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) send(message notify.Message, targets []string) (*SendResponse, error) {
+	if message.Title == "" && message.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "title or text is required")
+	}
+	if len(targets) == 0 {
+		targets = s.defaults
+	}
+	for _, name := range targets {
+		if _, ok := s.targets[name]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown target %s", name)
+		}
+	}
+
+	resp := &SendResponse{}
+	for _, name := range targets {
+		if err := s.targets[name].Send(message); err != nil {
+			if resp.Errors == nil {
+				resp.Errors = map[string]string{}
+			}
+			resp.Errors[name] = err.Error()
+			continue
+		}
+		resp.Sent = append(resp.Sent, name)
+	}
+	return resp, nil
+}
+
+func (s *Server) authorize(ctx context.Context) error {
+	if s.token == "" {
+		if s.allowUnauthenticated {
+			return nil
+		}
+		return status.Error(codes.FailedPrecondition, "token not configured, use WithToken or AllowUnauthenticated")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token := strings.TrimPrefix(value, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid token")
+}
+
+type NotifyServiceServer interface {
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+	SendTemplate(context.Context, *SendTemplateRequest) (*SendResponse, error)
+	SendStream(NotifyService_SendStreamServer) error
+}
+
+type NotifyService_SendStreamServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
+}
+
+type sendStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *sendStreamServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sendStreamServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceDesc 對應 protoc-gen-go-grpc 產生的 NotifyService_ServiceDesc
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "notify.v1.NotifyService",
+	HandlerType: (*NotifyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Send", Handler: sendHandler},
+		{MethodName: "SendTemplate", Handler: sendTemplateHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SendStream",
+			Handler:       sendStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "notify.proto",
+}
+
+func sendHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifyServiceServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/notify.v1.NotifyService/Send"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifyServiceServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func sendTemplateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifyServiceServer).SendTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/notify.v1.NotifyService/SendTemplate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifyServiceServer).SendTemplate(ctx, req.(*SendTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func sendStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NotifyServiceServer).SendStream(&sendStreamServer{stream})
+}
+
+// codec 本服務的訊息使用手寫編解碼，其他服務交回原本的 proto codec，可與其他服務共用同一個 grpc.Server
+type codec struct {
+	fallback encoding.Codec
+}
+
+func Codec() encoding.Codec {
+	return codec{fallback: encoding.GetCodec("proto")}
+}
+
+// ServerCodec 建立 grpc.Server 時使用的選項
+func ServerCodec() grpc.ServerOption {
+	return grpc.ForceServerCodec(Codec())
+}
+
+func (c codec) Marshal(v interface{}) ([]byte, error) {
+	if m, ok := v.(wireMessage); ok {
+		return m.marshal(), nil
+	}
+	if c.fallback == nil {
+		return nil, fmt.Errorf("notifygrpc: cannot marshal %T", v)
+	}
+	return c.fallback.Marshal(v)
+}
+
+func (c codec) Unmarshal(data []byte, v interface{}) error {
+	if m, ok := v.(wireMessage); ok {
+		return m.unmarshal(data)
+	}
+	if c.fallback == nil {
+		return fmt.Errorf("notifygrpc: cannot unmarshal %T", v)
+	}
+	return c.fallback.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
+
+// Client 呼叫 NotifyService 的簡易 client，其他語言可直接以 notify.proto 產生
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+func (c *Client) Send(ctx context.Context, req *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	opts = append([]grpc.CallOption{grpc.ForceCodec(Codec())}, opts...)
+	if err := c.conn.Invoke(ctx, "/notify.v1.NotifyService/Send", req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) SendTemplate(ctx context.Context, req *SendTemplateRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	opts = append([]grpc.CallOption{grpc.ForceCodec(Codec())}, opts...)
+	if err := c.conn.Invoke(ctx, "/notify.v1.NotifyService/SendTemplate", req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
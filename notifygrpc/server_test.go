@@ -0,0 +1,41 @@
+package notifygrpc
+
+import (
+	"context"
+	"testing"
+
+	notify "github.com/gps-gaming/notify-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestServerAuth(t *testing.T) {
+	targets := map[string]*notify.Notify{"ops": notify.New()}
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		token string
+		want  codes.Code
+	}{
+		{"no token configured", nil, "", codes.FailedPrecondition},
+		{"no token configured with metadata", nil, "Bearer anything", codes.FailedPrecondition},
+		{"allow unauthenticated", []Option{AllowUnauthenticated()}, "", codes.OK},
+		{"missing token", []Option{WithToken("s3cret")}, "", codes.Unauthenticated},
+		{"wrong token", []Option{WithToken("s3cret")}, "Bearer wrong", codes.Unauthenticated},
+		{"valid token", []Option{WithToken("s3cret")}, "Bearer s3cret", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.token))
+			}
+			_, err := New(targets, tt.opts...).Send(ctx, &SendRequest{Text: "disk full"})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %v, want %v: %v", got, tt.want, err)
+			}
+		})
+	}
+}
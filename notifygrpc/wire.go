@@ -0,0 +1,253 @@
+package notifygrpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+// 以下型別對應 notify.proto，手寫 protobuf wire format 編解碼以避免依賴 protoc 產生的程式碼
+
+type SendRequest struct {
+	Title    string
+	Text     string
+	Severity int32
+	Status   int32
+	Fields   map[string]string
+	URL      string
+	Key      string
+	Targets  []string
+}
+
+type SendTemplateRequest struct {
+	Template string
+	Data     map[string]string
+	Title    string
+	Severity int32
+	Status   int32
+	Key      string
+	Targets  []string
+}
+
+type SendResponse struct {
+	Sent   []string
+	Errors map[string]string
+}
+
+// wireMessage 由 codec 判斷是否使用手寫編解碼
+type wireMessage interface {
+	marshal() []byte
+	unmarshal([]byte) error
+}
+
+func (m *SendRequest) marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, m.Title)
+	b = appendString(b, 2, m.Text)
+	b = appendVarint(b, 3, uint64(m.Severity))
+	b = appendVarint(b, 4, uint64(m.Status))
+	b = appendMap(b, 5, m.Fields)
+	b = appendString(b, 6, m.URL)
+	b = appendString(b, 7, m.Key)
+	for _, target := range m.Targets {
+		b = appendBytes(b, 8, []byte(target))
+	}
+	return b
+}
+
+func (m *SendRequest) unmarshal(data []byte) error {
+	*m = SendRequest{}
+	return parseFields(data, func(num int, varint uint64, value []byte) error {
+		switch num {
+		case 1:
+			m.Title = string(value)
+		case 2:
+			m.Text = string(value)
+		case 3:
+			m.Severity = int32(varint)
+		case 4:
+			m.Status = int32(varint)
+		case 5:
+			return parseMapEntry(value, &m.Fields)
+		case 6:
+			m.URL = string(value)
+		case 7:
+			m.Key = string(value)
+		case 8:
+			m.Targets = append(m.Targets, string(value))
+		}
+		return nil
+	})
+}
+
+func (m *SendTemplateRequest) marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, m.Template)
+	b = appendMap(b, 2, m.Data)
+	b = appendString(b, 3, m.Title)
+	b = appendVarint(b, 4, uint64(m.Severity))
+	b = appendVarint(b, 5, uint64(m.Status))
+	b = appendString(b, 6, m.Key)
+	for _, target := range m.Targets {
+		b = appendBytes(b, 7, []byte(target))
+	}
+	return b
+}
+
+func (m *SendTemplateRequest) unmarshal(data []byte) error {
+	*m = SendTemplateRequest{}
+	return parseFields(data, func(num int, varint uint64, value []byte) error {
+		switch num {
+		case 1:
+			m.Template = string(value)
+		case 2:
+			return parseMapEntry(value, &m.Data)
+		case 3:
+			m.Title = string(value)
+		case 4:
+			m.Severity = int32(varint)
+		case 5:
+			m.Status = int32(varint)
+		case 6:
+			m.Key = string(value)
+		case 7:
+			m.Targets = append(m.Targets, string(value))
+		}
+		return nil
+	})
+}
+
+func (m *SendResponse) marshal() []byte {
+	var b []byte
+	for _, sent := range m.Sent {
+		b = appendBytes(b, 1, []byte(sent))
+	}
+	return appendMap(b, 2, m.Errors)
+}
+
+func (m *SendResponse) unmarshal(data []byte) error {
+	*m = SendResponse{}
+	return parseFields(data, func(num int, varint uint64, value []byte) error {
+		switch num {
+		case 1:
+			m.Sent = append(m.Sent, string(value))
+		case 2:
+			return parseMapEntry(value, &m.Errors)
+		}
+		return nil
+	})
+}
+
+func appendTag(b []byte, num, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(wireType))
+}
+
+// appendVarint proto3 預設值不編碼
+func appendVarint(b []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, num, 0)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendBytes(b []byte, num int, v []byte) []byte {
+	b = appendTag(b, num, 2)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendString(b []byte, num int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return appendBytes(b, num, []byte(v))
+}
+
+// appendMap map 編碼為 repeated entry { key = 1; value = 2; }，依 key 排序讓輸出穩定
+func appendMap(b []byte, num int, m map[string]string) []byte {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var entry []byte
+		entry = appendString(entry, 1, key)
+		entry = appendString(entry, 2, m[key])
+		b = appendBytes(b, num, entry)
+	}
+	return b
+}
+
+var errInvalidWire = errors.New("invalid protobuf encoding")
+
+// parseFields 逐一解析欄位，varint 欄位傳入數值，length-delimited 欄位傳入內容，其他型別略過
+func parseFields(data []byte, fn func(num int, varint uint64, value []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errInvalidWire
+		}
+		data = data[n:]
+		num, wireType := int(tag>>3), int(tag&7)
+
+		var varint uint64
+		var value []byte
+		switch wireType {
+		case 0:
+			varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errInvalidWire
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return errInvalidWire
+			}
+			data = data[8:]
+			continue
+		case 2:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return errInvalidWire
+			}
+			value = data[n : n+int(size)]
+			data = data[n+int(size):]
+		case 5:
+			if len(data) < 4 {
+				return errInvalidWire
+			}
+			data = data[4:]
+			continue
+		default:
+			return errInvalidWire
+		}
+
+		if err := fn(num, varint, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseMapEntry(data []byte, m *map[string]string) error {
+	var key, value string
+	err := parseFields(data, func(num int, _ uint64, v []byte) error {
+		switch num {
+		case 1:
+			key = string(v)
+		case 2:
+			value = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if *m == nil {
+		*m = map[string]string{}
+	}
+	(*m)[key] = value
+	return nil
+}
@@ -0,0 +1,186 @@
+package notifygrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	notify "github.com/gps-gaming/notify-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// 以下 golden bytes 依 notify.proto 的欄位編號手動編碼
+func TestWireGolden(t *testing.T) {
+	tests := []struct {
+		name    string
+		message wireMessage
+		want    string
+	}{
+		{
+			name: "SendRequest",
+			message: &SendRequest{
+				Title:    "t",
+				Text:     "x",
+				Severity: 2,
+				Fields:   map[string]string{"b": "2", "a": "1"},
+				URL:      "u",
+				Key:      "k",
+				Targets:  []string{"ops", "oncall"},
+			},
+			want: "0a0174" + // title
+				"120178" + // text
+				"1802" + // severity，status 為 0 不編碼
+				"2a060a0161120131" + "2a060a0162120132" + // fields 依 key 排序
+				"320175" + // url
+				"3a016b" + // key
+				"42036f7073" + "42066f6e63616c6c", // targets
+		},
+		{
+			name:    "SendRequest negative enum",
+			message: &SendRequest{Severity: -1},
+			want:    "18ffffffffffffffffff01",
+		},
+		{
+			name: "SendTemplateRequest",
+			message: &SendTemplateRequest{
+				Template: "{{.host}}",
+				Data:     map[string]string{"host": "db-1"},
+				Status:   2,
+				Targets:  []string{"ops"},
+			},
+			want: "0a097b7b2e686f73747d7d" + // template
+				"120c0a04686f73741204" + hex.EncodeToString([]byte("db-1")) + // data
+				"2802" + // status
+				"3a036f7073", // targets
+		},
+		{
+			name:    "SendResponse",
+			message: &SendResponse{Sent: []string{"ops"}, Errors: map[string]string{"oncall": "timeout"}},
+			want:    "0a036f7073" + "12110a066f6e63616c6c120774696d656f7574",
+		},
+		{
+			name:    "empty",
+			message: &SendResponse{},
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.message.marshal()
+			if got := hex.EncodeToString(data); got != tt.want {
+				t.Fatalf("marshal =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			decoded := reflect.New(reflect.TypeOf(tt.message).Elem()).Interface().(wireMessage)
+			if err := decoded.unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, tt.message) {
+				t.Fatalf("unmarshal = %+v, want %+v", decoded, tt.message)
+			}
+		})
+	}
+}
+
+func TestWireSkipsUnknownFields(t *testing.T) {
+	// 欄位 9 varint、10 fixed64、11 fixed32、12 bytes 都不在 SendRequest 中
+	data, _ := hex.DecodeString("4801" + "510102030405060708" + "5d01020304" + "6201ff" + "0a0174")
+	var m SendRequest
+	if err := m.unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if m.Title != "t" {
+		t.Fatalf("Title = %q", m.Title)
+	}
+}
+
+func TestWireInvalid(t *testing.T) {
+	for _, input := range []string{
+		"0a05746974",   // 長度超過剩餘內容
+		"18",           // varint 缺少內容
+		"51010203",     // fixed64 不足 8 bytes
+		"0b",           // 不支援的 group wire type
+		"2a040a017412", // map entry 內容截斷
+	} {
+		data, _ := hex.DecodeString(input)
+		var m SendRequest
+		if err := m.unmarshal(data); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+type recordNotifier struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (r *recordNotifier) Send(_ *http.Client, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, message)
+	return r.err
+}
+
+func (r *recordNotifier) SendRaw(_ *http.Client, _ map[string]interface{}) error {
+	return r.err
+}
+
+// TestClientServer 透過 bufconn 以實際的 gRPC 連線驗證 codec 與 service 註冊
+func TestClientServer(t *testing.T) {
+	ops := &recordNotifier{}
+	oncall := &recordNotifier{err: errors.New("timeout")}
+	targets := map[string]*notify.Notify{"ops": notify.New(), "oncall": notify.New()}
+	targets["ops"].Notifiers = append(targets["ops"].Notifiers, ops)
+	targets["oncall"].Notifiers = append(targets["oncall"].Notifiers, oncall)
+
+	listener := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(ServerCodec())
+	Register(gs, New(targets, WithToken("s3cret")))
+	go gs.Serve(listener)
+	defer gs.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer s3cret")
+	client := NewClient(conn)
+
+	resp, err := client.Send(ctx, &SendRequest{Title: "disk full", Text: "db-1", Targets: []string{"ops", "oncall"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Sent, []string{"ops"}) || resp.Errors["oncall"] != "timeout" {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	resp, err = client.SendTemplate(ctx, &SendTemplateRequest{Template: "{{.host}} is down", Data: map[string]string{"host": "db-2"}, Targets: []string{"ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Sent, []string{"ops"}) {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+	if len(ops.sent) != 2 || !strings.Contains(ops.sent[0], "disk full") || !strings.Contains(ops.sent[1], "db-2 is down") {
+		t.Fatalf("sent %q", ops.sent)
+	}
+}
@@ -0,0 +1,185 @@
+// Package notifyhost 定期取樣主機的磁碟、記憶體、CPU load 與程序狀態，超過門檻與恢復時通知；
+// 取樣讀取 /proc，目前僅支援 Linux
+package notifyhost
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+type Option func(*Monitor)
+
+// Disk path 所在檔案系統使用率達 percent (0-100) 時告警
+func Disk(path string, percent float64) Option {
+	return func(m *Monitor) {
+		m.checks = append(m.checks, &check{
+			name:     "Disk usage " + path,
+			severity: notify.SeverityWarning,
+			sample:   func() (float64, error) { return diskUsage(path) },
+			bad:      func(v float64) bool { return v >= percent },
+			describe: func(v float64) string { return fmt.Sprintf("%.1f%% used (threshold %g%%)", v, percent) },
+		})
+	}
+}
+
+// Memory 記憶體使用率 (扣除可回收的 cache) 達 percent 時告警
+func Memory(percent float64) Option {
+	return func(m *Monitor) {
+		m.checks = append(m.checks, &check{
+			name:     "Memory usage",
+			severity: notify.SeverityWarning,
+			sample:   memoryUsage,
+			bad:      func(v float64) bool { return v >= percent },
+			describe: func(v float64) string { return fmt.Sprintf("%.1f%% used (threshold %g%%)", v, percent) },
+		})
+	}
+}
+
+// Load 1 分鐘 load average 除以 CPU 數達 perCPU 時告警，例如 1.5
+func Load(perCPU float64) Option {
+	return func(m *Monitor) {
+		cpus := float64(runtime.NumCPU())
+		m.checks = append(m.checks, &check{
+			name:     "CPU load",
+			severity: notify.SeverityWarning,
+			sample: func() (float64, error) {
+				load, err := loadAverage()
+				return load / cpus, err
+			},
+			bad: func(v float64) bool { return v >= perCPU },
+			describe: func(v float64) string {
+				return fmt.Sprintf("load %.2f on %d CPUs (%.2f per CPU, threshold %g)", v*cpus, int(cpus), v, perCPU)
+			},
+		})
+	}
+}
+
+// Process 指定程序不存在時告警；name 為程序名稱，或以 "/" 開頭的 pid 檔路徑
+func Process(name string) Option {
+	return func(m *Monitor) {
+		m.checks = append(m.checks, &check{
+			name:     "Process " + name,
+			severity: notify.SeverityError,
+			sample: func() (float64, error) {
+				count, err := processCount(name)
+				return float64(count), err
+			},
+			bad: func(v float64) bool { return v == 0 },
+			describe: func(v float64) string {
+				if v == 0 {
+					return "not running"
+				}
+				return fmt.Sprintf("%d running", int(v))
+			},
+		})
+	}
+}
+
+// WithInterval 取樣間隔，預設 1 分鐘
+func WithInterval(interval time.Duration) Option {
+	return func(m *Monitor) { m.interval = interval }
+}
+
+// WithSustain 連續幾次取樣超過門檻才告警，避免瞬間尖峰誤報，預設 2 次
+func WithSustain(samples int) Option {
+	return func(m *Monitor) { m.sustain = samples }
+}
+
+type check struct {
+	name     string
+	severity notify.Severity
+	sample   func() (float64, error)
+	bad      func(float64) bool
+	describe func(float64) string
+
+	streak int
+	firing bool
+}
+
+type Monitor struct {
+	notify   *notify.Notify
+	checks   []*check
+	interval time.Duration
+	sustain  int
+	hostname string
+}
+
+func New(n *notify.Notify, opts ...Option) *Monitor {
+	m := &Monitor{
+		notify:   n,
+		interval: time.Minute,
+		sustain:  2,
+	}
+	m.hostname, _ = os.Hostname()
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Run 依間隔取樣直到 ctx 結束
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 執行一輪取樣，狀態改變時通知；不使用 Run 時可自行排程呼叫
+func (m *Monitor) Check() {
+	for _, c := range m.checks {
+		value, err := c.sample()
+		if err != nil {
+			log.Println("notifyhost:", c.name, err)
+			continue
+		}
+
+		if !c.bad(value) {
+			c.streak = 0
+			if c.firing {
+				c.firing = false
+				m.send(c, value, notify.SeverityInfo, notify.StatusResolved)
+			}
+			continue
+		}
+
+		c.streak++
+		if !c.firing && c.streak >= m.sustain {
+			c.firing = true
+			m.send(c, value, c.severity, notify.StatusFiring)
+		}
+	}
+}
+
+func (m *Monitor) send(c *check, value float64, severity notify.Severity, status notify.Status) {
+	title := c.name + " on " + m.hostname
+	if status == notify.StatusResolved {
+		title += " recovered"
+	}
+
+	m.notify.Send(notify.Message{
+		Title:    title,
+		Text:     c.describe(value),
+		Severity: severity,
+		Status:   status,
+		Fields: []notify.Field{
+			{Name: "host", Value: m.hostname},
+			{Name: "value", Value: strconv.FormatFloat(value, 'f', 2, 64)},
+		},
+		Key: "host/" + m.hostname + "/" + c.name,
+	})
+}
@@ -0,0 +1,117 @@
+package notifyhost
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// diskUsage 計算方式同 df：used / (used + 一般使用者可用空間)
+func diskUsage(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	used := float64(stat.Blocks-stat.Bfree) * float64(stat.Bsize)
+	available := float64(stat.Bavail) * float64(stat.Bsize)
+	if used+available == 0 {
+		return 0, nil
+	}
+	return used / (used + available) * 100, nil
+}
+
+func memoryUsage() (float64, error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	values := map[string]float64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) > 0 {
+			values[name], _ = strconv.ParseFloat(fields[0], 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	total, available := values["MemTotal"], values["MemAvailable"]
+	if total == 0 {
+		return 0, fmt.Errorf("MemTotal not found in /proc/meminfo")
+	}
+	return (total - available) / total * 100, nil
+}
+
+func loadAverage() (float64, error) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected /proc/loadavg format")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// processCount name 以 "/" 開頭時視為 pid 檔，否則比對 comm 或執行檔名稱
+func processCount(name string) (int, error) {
+	if strings.HasPrefix(name, "/") {
+		data, err := os.ReadFile(name)
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return 0, fmt.Errorf("invalid pid file %s: %v", name, err)
+		}
+		if _, err := os.Stat(fmt.Sprintf("/proc/%d", pid)); err != nil {
+			return 0, nil
+		}
+		return 1, nil
+	}
+
+	dirs, err := filepath.Glob("/proc/[0-9]*")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, dir := range dirs {
+		comm, err := os.ReadFile(dir + "/comm")
+		if err != nil {
+			continue
+		}
+		// comm 最長 15 字元，較長的名稱改比對 cmdline 的執行檔
+		if strings.TrimSpace(string(comm)) == name {
+			count++
+			continue
+		}
+		cmdline, err := os.ReadFile(dir + "/cmdline")
+		if err != nil || len(cmdline) == 0 {
+			continue
+		}
+		argv0, _, _ := bytes.Cut(cmdline, []byte{0})
+		if filepath.Base(string(argv0)) == name {
+			count++
+		}
+	}
+	return count, nil
+}
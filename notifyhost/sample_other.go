@@ -0,0 +1,23 @@
+//go:build !linux
+
+package notifyhost
+
+import "errors"
+
+var errUnsupported = errors.New("host metrics are only supported on linux")
+
+func diskUsage(path string) (float64, error) {
+	return 0, errUnsupported
+}
+
+func memoryUsage() (float64, error) {
+	return 0, errUnsupported
+}
+
+func loadAverage() (float64, error) {
+	return 0, errUnsupported
+}
+
+func processCount(name string) (int, error) {
+	return 0, errUnsupported
+}
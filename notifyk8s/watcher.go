@@ -0,0 +1,348 @@
+// Package notifyk8s 監看 Kubernetes pod、job 與 Warning event，
+// 在 CrashLoopBackOff、OOMKilled、Job 失敗等狀況發出通知，並依 namespace 或 label 分流
+package notifyk8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+type Option func(*Watcher)
+
+// WithNamespace 只監看指定 namespace，預設為全部
+func WithNamespace(namespace string) Option {
+	return func(w *Watcher) { w.namespace = namespace }
+}
+
+// RouteNamespace 指定 namespace 的通知改送到另一組通道
+func RouteNamespace(namespace string, n *notify.Notify) Option {
+	return func(w *Watcher) {
+		w.routes = append(w.routes, route{namespace: namespace, notify: n})
+	}
+}
+
+// Route 符合 label selector (例如 "team=payments,tier!=dev") 的通知改送到另一組通道，依加入順序比對
+func Route(selector string, n *notify.Notify) Option {
+	return func(w *Watcher) {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			w.err = fmt.Errorf("invalid label selector %q: %v", selector, err)
+			return
+		}
+		w.routes = append(w.routes, route{selector: parsed, notify: n})
+	}
+}
+
+// WithEventReasons 轉發的 Warning event reason，預設 FailedScheduling、FailedMount、Evicted、NodeNotReady、FailedCreate
+func WithEventReasons(reasons ...string) Option {
+	return func(w *Watcher) {
+		w.reasons = map[string]bool{}
+		for _, reason := range reasons {
+			w.reasons[reason] = true
+		}
+	}
+}
+
+// WithThrottle 相同物件與原因在 window 內只送一次，且每個 window 最多送 burst 則，預設 10 分鐘 20 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(w *Watcher) { w.throttle = notify.NewThrottle(window, burst) }
+}
+
+// queueSize 待送通知的上限，超過時捨棄，避免通道緩慢時阻塞 informer
+const queueSize = 256
+
+type queued struct {
+	notify  *notify.Notify
+	message notify.Message
+}
+
+type route struct {
+	namespace string
+	selector  labels.Selector
+	notify    *notify.Notify
+}
+
+type Watcher struct {
+	client    kubernetes.Interface
+	notify    *notify.Notify
+	namespace string
+	routes    []route
+	reasons   map[string]bool
+	throttle  *notify.Throttle
+
+	pods    listerscorev1.PodLister
+	started time.Time
+	queue   chan queued
+	err     error
+}
+
+func New(client kubernetes.Interface, n *notify.Notify, opts ...Option) *Watcher {
+	w := &Watcher{
+		client:   client,
+		notify:   n,
+		throttle: notify.NewThrottle(10*time.Minute, 20),
+	}
+	WithEventReasons("FailedScheduling", "FailedMount", "Evicted", "NodeNotReady", "FailedCreate")(w)
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run 監看直到 ctx 結束；只通知啟動後發生的狀態變化，不會重送既有狀態
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(w.client, 0, informers.WithNamespace(w.namespace))
+	pods := factory.Core().V1().Pods()
+	w.pods = pods.Lister()
+	w.started = time.Now()
+
+	// informer handler 只負責排入佇列，由 worker 依序送出，避免慢速通道拖住 watch
+	w.queue = make(chan queued, queueSize)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go w.worker(stop, done)
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	if _, err := pods.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, cur interface{}) {
+			w.podUpdated(old.(*corev1.Pod), cur.(*corev1.Pod))
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := factory.Batch().V1().Jobs().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, cur interface{}) {
+			w.jobUpdated(old.(*batchv1.Job), cur.(*batchv1.Job))
+		},
+	}); err != nil {
+		return err
+	}
+
+	// event 重複發生時只會更新 count，因此 Add 與 Update 都要處理
+	if _, err := factory.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.eventSeen(obj.(*corev1.Event))
+		},
+		UpdateFunc: func(_, cur interface{}) {
+			w.eventSeen(cur.(*corev1.Event))
+		},
+	}); err != nil {
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	for informer, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok && ctx.Err() == nil {
+			return fmt.Errorf("failed to sync %v informer", informer)
+		}
+	}
+
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+func (w *Watcher) podUpdated(old, cur *corev1.Pod) {
+	previous := map[string]corev1.ContainerStatus{}
+	for _, status := range containerStatuses(old) {
+		previous[status.Name] = status
+	}
+
+	for _, status := range containerStatuses(cur) {
+		before := previous[status.Name]
+
+		if waiting := status.State.Waiting; waiting != nil && (before.State.Waiting == nil || before.State.Waiting.Reason != waiting.Reason) {
+			switch waiting.Reason {
+			case "CrashLoopBackOff":
+				w.podNotify(cur, status, notify.SeverityError, waiting.Reason, waiting.Message, status.LastTerminationState.Terminated)
+			case "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName":
+				w.podNotify(cur, status, notify.SeverityWarning, waiting.Reason, waiting.Message, nil)
+			}
+		}
+
+		// restartPolicy Never 時停在 terminated，否則會記錄在 LastTerminationState 並增加 restart 次數
+		if terminated := status.State.Terminated; terminated != nil && terminated.Reason == "OOMKilled" && before.State.Terminated == nil {
+			w.podNotify(cur, status, notify.SeverityError, terminated.Reason, terminated.Message, terminated)
+		} else if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Reason == "OOMKilled" && status.RestartCount > before.RestartCount {
+			w.podNotify(cur, status, notify.SeverityError, terminated.Reason, terminated.Message, terminated)
+		}
+	}
+}
+
+// containerStatuses 複製一份，避免 append 改到 informer cache 內的物件
+func containerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
+
+func (w *Watcher) podNotify(pod *corev1.Pod, status corev1.ContainerStatus, severity notify.Severity, reason, text string, terminated *corev1.ContainerStateTerminated) {
+	fields := []notify.Field{
+		{Name: "namespace", Value: pod.Namespace},
+		{Name: "pod", Value: pod.Name},
+		{Name: "container", Value: status.Name},
+		{Name: "image", Value: status.Image},
+		{Name: "node", Value: pod.Spec.NodeName},
+		{Name: "restarts", Value: strconv.Itoa(int(status.RestartCount))},
+	}
+	if terminated != nil {
+		fields = append(fields, notify.Field{Name: "exit code", Value: strconv.Itoa(int(terminated.ExitCode))})
+		if terminated.Reason != "" && terminated.Reason != reason {
+			fields = append(fields, notify.Field{Name: "last state", Value: terminated.Reason})
+		}
+	}
+
+	w.send(pod.Namespace, pod.Labels, notify.Message{
+		Title:    fmt.Sprintf("%s: %s/%s", reason, pod.Namespace, pod.Name),
+		Text:     text,
+		Severity: severity,
+		Fields:   fields,
+		Key:      fmt.Sprintf("k8s/pod/%s/%s/%s/%s", pod.Namespace, pod.Name, status.Name, reason),
+	})
+}
+
+func (w *Watcher) jobUpdated(old, cur *batchv1.Job) {
+	condition := jobFailed(cur)
+	if condition == nil || jobFailed(old) != nil {
+		return
+	}
+
+	fields := []notify.Field{
+		{Name: "namespace", Value: cur.Namespace},
+		{Name: "job", Value: cur.Name},
+		{Name: "reason", Value: condition.Reason},
+		{Name: "failed pods", Value: strconv.Itoa(int(cur.Status.Failed))},
+	}
+	for _, owner := range cur.OwnerReferences {
+		if owner.Kind == "CronJob" {
+			fields = append(fields, notify.Field{Name: "cronjob", Value: owner.Name})
+		}
+	}
+
+	w.send(cur.Namespace, cur.Labels, notify.Message{
+		Title:    fmt.Sprintf("Job failed: %s/%s", cur.Namespace, cur.Name),
+		Text:     condition.Message,
+		Severity: notify.SeverityError,
+		Fields:   fields,
+		Key:      fmt.Sprintf("k8s/job/%s/%s", cur.Namespace, cur.Name),
+	})
+}
+
+func jobFailed(job *batchv1.Job) *batchv1.JobCondition {
+	for i, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) eventSeen(event *corev1.Event) {
+	if event.Type != corev1.EventTypeWarning || !w.reasons[event.Reason] {
+		return
+	}
+
+	at := event.LastTimestamp.Time
+	if at.IsZero() {
+		at = event.EventTime.Time
+	}
+	if at.IsZero() {
+		at = event.CreationTimestamp.Time
+	}
+	if at.Before(w.started) {
+		return
+	}
+
+	object := event.InvolvedObject
+	var objectLabels map[string]string
+	if object.Kind == "Pod" {
+		if pod, err := w.pods.Pods(object.Namespace).Get(object.Name); err == nil {
+			objectLabels = pod.Labels
+		}
+	}
+
+	fields := []notify.Field{
+		{Name: "namespace", Value: object.Namespace},
+		{Name: "object", Value: object.Kind + "/" + object.Name},
+	}
+	if event.Count > 1 {
+		fields = append(fields, notify.Field{Name: "count", Value: strconv.Itoa(int(event.Count))})
+	}
+	if event.Source.Component != "" {
+		fields = append(fields, notify.Field{Name: "source", Value: event.Source.Component})
+	}
+
+	w.send(object.Namespace, objectLabels, notify.Message{
+		Title:    fmt.Sprintf("%s: %s/%s", event.Reason, object.Namespace, object.Name),
+		Text:     event.Message,
+		Severity: notify.SeverityWarning,
+		Fields:   fields,
+		Key:      fmt.Sprintf("k8s/event/%s/%s/%s/%s", object.Namespace, object.Kind, object.Name, event.Reason),
+	})
+}
+
+// worker 依序送出佇列中的通知，stop 關閉後送完剩餘的再結束
+func (w *Watcher) worker(stop, done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case item := <-w.queue:
+			item.notify.Send(item.message)
+		case <-stop:
+			for {
+				select {
+				case item := <-w.queue:
+					item.notify.Send(item.message)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send 依序比對 route，都不符合時使用預設通道
+func (w *Watcher) send(namespace string, objectLabels map[string]string, message notify.Message) {
+	if !w.throttle.Allow(message.Key) {
+		return
+	}
+	if dropped := w.throttle.Dropped(); dropped > 0 {
+		message.Fields = append(message.Fields, notify.Field{Name: "suppressed", Value: strconv.Itoa(dropped)})
+	}
+
+	n := w.notify
+	for _, r := range w.routes {
+		if (r.namespace != "" && r.namespace == namespace) || (r.selector != nil && r.selector.Matches(labels.Set(objectLabels))) {
+			n = r.notify
+			break
+		}
+	}
+	if n == nil {
+		return
+	}
+	select {
+	case w.queue <- queued{notify: n, message: message}:
+	default:
+		log.Println("notifyk8s queue full, dropped", message.Key)
+	}
+}
@@ -0,0 +1,104 @@
+package notifyk8s
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// blockingNotifier 在 release 關閉前不會回傳，模擬緩慢的通道
+type blockingNotifier struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+
+	mu   sync.Mutex
+	sent []string
+}
+
+func (b *blockingNotifier) Send(_ *http.Client, message string) error {
+	b.once.Do(func() { close(b.started) })
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, message)
+	return nil
+}
+
+func (b *blockingNotifier) SendRaw(_ *http.Client, _ map[string]interface{}) error {
+	return nil
+}
+
+func (b *blockingNotifier) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.sent)
+}
+
+func TestWatcherSendsOutsideInformerHandler(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"}}
+	client := fake.NewSimpleClientset(job)
+
+	slow := &blockingNotifier{started: make(chan struct{}), release: make(chan struct{})}
+	n := notify.New()
+	n.Notifiers = append(n.Notifiers, slow)
+	w := New(client, n, WithThrottle(time.Nanosecond, 1000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan error, 1)
+	go func() { finished <- w.Run(ctx) }()
+
+	// informer 同步前的變更會被當成 Add 而不觸發通知，因此反覆切換 Job 狀態
+	deadline := time.After(10 * time.Second)
+	failed := false
+	toggle := func() {
+		failed = !failed
+		job := job.DeepCopy()
+		if failed {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+		}
+		if _, err := client.BatchV1().Jobs("default").UpdateStatus(ctx, job, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for job failure notifications")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// 第一則通知卡在 notifier 之後，handler 仍須持續收到並排入後續的通知
+	for started := false; !started; {
+		toggle()
+		select {
+		case <-slow.started:
+			started = true
+		default:
+		}
+	}
+	for len(w.queue) == 0 {
+		toggle()
+	}
+
+	cancel()
+	close(slow.release)
+	select {
+	case err := <-finished:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if slow.count() < 2 {
+		t.Fatal("queued notification was not sent before Run returned")
+	}
+}
@@ -0,0 +1,298 @@
+// Package notifylambda 將 SNS、SQS、EventBridge 與 CloudWatch alarm 事件轉為通知，
+// Handler 的簽名相容 aws-lambda-go 的 lambda.Start：
+//
+//	lambda.Start(notifylambda.Handler(notify.New().Telegram(botToken, chatID)))
+package notifylambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+type Option func(*handler)
+
+// WithSeverity 無法判斷嚴重度的一般事件 (SNS 訊息、EventBridge 事件) 使用的 severity，預設 Info
+func WithSeverity(severity notify.Severity) Option {
+	return func(h *handler) { h.severity = severity }
+}
+
+// BatchResponse SQS 觸發時回報處理失敗的訊息，
+// 需在 event source mapping 啟用 ReportBatchItemFailures，否則失敗的訊息會被刪除
+type BatchResponse struct {
+	BatchItemFailures []BatchItemFailure `json:"batchItemFailures"`
+}
+
+type BatchItemFailure struct {
+	ItemIdentifier string `json:"itemIdentifier"`
+}
+
+type handler struct {
+	notify   *notify.Notify
+	severity notify.Severity
+}
+
+// Handler 依事件格式自動判斷來源；SQS 的 body 可以是 SNS 訂閱 (未啟用 raw delivery)、EventBridge 事件或純文字
+func Handler(n *notify.Notify, opts ...Option) func(ctx context.Context, event json.RawMessage) (*BatchResponse, error) {
+	h := &handler{notify: n, severity: notify.SeverityInfo}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h.handle
+}
+
+type lambdaRecords struct {
+	Records []struct {
+		EventSource string          `json:"eventSource"`
+		MessageID   string          `json:"messageId"`
+		Body        string          `json:"body"`
+		Sns         snsNotification `json:"Sns"`
+	} `json:"Records"`
+}
+
+type snsNotification struct {
+	Type      string `json:"Type"`
+	MessageID string `json:"MessageId"`
+	TopicArn  string `json:"TopicArn"`
+	Subject   string `json:"Subject"`
+	Message   string `json:"Message"`
+}
+
+type eventBridgeEvent struct {
+	ID         string          `json:"id"`
+	DetailType string          `json:"detail-type"`
+	Source     string          `json:"source"`
+	Account    string          `json:"account"`
+	Region     string          `json:"region"`
+	Resources  []string        `json:"resources"`
+	Detail     json.RawMessage `json:"detail"`
+
+	// CloudWatch alarm 直接以 Lambda 作為 alarm action 時的格式
+	AlarmArn  string          `json:"alarmArn"`
+	AlarmData json.RawMessage `json:"alarmData"`
+}
+
+// cloudWatchAlarm 經由 SNS 送出的 alarm 通知
+type cloudWatchAlarm struct {
+	AlarmName        string `json:"AlarmName"`
+	AlarmDescription string `json:"AlarmDescription"`
+	AlarmArn         string `json:"AlarmArn"`
+	AWSAccountID     string `json:"AWSAccountId"`
+	NewStateValue    string `json:"NewStateValue"`
+	NewStateReason   string `json:"NewStateReason"`
+	OldStateValue    string `json:"OldStateValue"`
+	Trigger          struct {
+		MetricName string `json:"MetricName"`
+		Namespace  string `json:"Namespace"`
+	} `json:"Trigger"`
+}
+
+// alarmStateChange EventBridge 與 Lambda alarm action 共用的 alarm 內容
+type alarmStateChange struct {
+	AlarmName string `json:"alarmName"`
+	State     struct {
+		Value  string `json:"value"`
+		Reason string `json:"reason"`
+	} `json:"state"`
+	PreviousState struct {
+		Value string `json:"value"`
+	} `json:"previousState"`
+	Configuration struct {
+		Description string `json:"description"`
+	} `json:"configuration"`
+}
+
+func (h *handler) handle(ctx context.Context, event json.RawMessage) (*BatchResponse, error) {
+	var records lambdaRecords
+	if json.Unmarshal(event, &records) == nil && len(records.Records) > 0 {
+		if records.Records[0].EventSource == "aws:sqs" {
+			return h.handleSQS(ctx, records)
+		}
+
+		for _, record := range records.Records {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if record.EventSource != "aws:sns" {
+				return nil, fmt.Errorf("unsupported event source %q", record.EventSource)
+			}
+			if err := h.notify.Send(h.snsMessage(record.Sns)); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	message, err := h.eventMessage(event)
+	if err != nil {
+		return nil, err
+	}
+	return nil, h.notify.Send(message)
+}
+
+func (h *handler) handleSQS(ctx context.Context, records lambdaRecords) (*BatchResponse, error) {
+	response := &BatchResponse{BatchItemFailures: []BatchItemFailure{}}
+	for _, record := range records.Records {
+		// 逾時前剩下的訊息全部回報失敗，留待重試
+		if ctx.Err() != nil || h.notify.Send(h.bodyMessage(record.Body)) != nil {
+			response.BatchItemFailures = append(response.BatchItemFailures, BatchItemFailure{ItemIdentifier: record.MessageID})
+		}
+	}
+	return response, nil
+}
+
+// bodyMessage 解析 SQS body：SNS envelope、EventBridge 事件，其餘視為純文字
+func (h *handler) bodyMessage(body string) notify.Message {
+	var sns snsNotification
+	if json.Unmarshal([]byte(body), &sns) == nil && sns.Type == "Notification" {
+		return h.snsMessage(sns)
+	}
+	if message, err := h.eventMessage(json.RawMessage(body)); err == nil {
+		return message
+	}
+	return notify.Message{Text: body, Severity: h.severity}
+}
+
+func (h *handler) snsMessage(sns snsNotification) notify.Message {
+	var alarm cloudWatchAlarm
+	if json.Unmarshal([]byte(sns.Message), &alarm) == nil && alarm.AlarmName != "" && alarm.NewStateValue != "" {
+		return snsAlarmMessage(alarm)
+	}
+	if message, err := h.eventMessage(json.RawMessage(sns.Message)); err == nil {
+		return message
+	}
+
+	return notify.Message{
+		Title:    sns.Subject,
+		Text:     sns.Message,
+		Severity: h.severity,
+		Key:      sns.MessageID,
+		Fields:   []notify.Field{{Name: "topic", Value: topicName(sns.TopicArn)}},
+	}
+}
+
+func (h *handler) eventMessage(event json.RawMessage) (notify.Message, error) {
+	var e eventBridgeEvent
+	if err := json.Unmarshal(event, &e); err != nil {
+		return notify.Message{}, fmt.Errorf("failed to parse event: %v", err)
+	}
+
+	if e.Source == "aws.cloudwatch" && (e.DetailType == "CloudWatch Alarm State Change" || len(e.AlarmData) > 0) {
+		data := e.Detail
+		arn := e.AlarmArn
+		if len(e.AlarmData) > 0 {
+			data = e.AlarmData
+		} else if len(e.Resources) > 0 {
+			arn = e.Resources[0]
+		}
+		var alarm alarmStateChange
+		if err := json.Unmarshal(data, &alarm); err != nil {
+			return notify.Message{}, fmt.Errorf("failed to parse alarm: %v", err)
+		}
+		return alarmMessage(alarm.AlarmName, arn, alarm.State.Value, alarm.PreviousState.Value, alarm.State.Reason, alarm.Configuration.Description), nil
+	}
+
+	if e.DetailType == "" || e.Source == "" {
+		return notify.Message{}, fmt.Errorf("unsupported event")
+	}
+
+	message := notify.Message{
+		Title:    e.DetailType,
+		Severity: h.severity,
+		Key:      e.ID,
+		Fields: []notify.Field{
+			{Name: "source", Value: e.Source},
+			{Name: "account", Value: e.Account},
+			{Name: "region", Value: e.Region},
+		},
+	}
+	if len(e.Resources) > 0 {
+		message.Fields = append(message.Fields, notify.Field{Name: "resources", Value: strings.Join(e.Resources, ", ")})
+	}
+
+	var detail map[string]interface{}
+	if json.Unmarshal(e.Detail, &detail) == nil {
+		if severity, ok := detail["severity"].(string); ok {
+			if parsed, err := notify.ParseSeverity(severity); err == nil {
+				message.Severity = parsed
+			}
+		}
+		message.Text = formatDetail(detail)
+	}
+	return message, nil
+}
+
+func snsAlarmMessage(alarm cloudWatchAlarm) notify.Message {
+	message := alarmMessage(alarm.AlarmName, alarm.AlarmArn, alarm.NewStateValue, alarm.OldStateValue, alarm.NewStateReason, alarm.AlarmDescription)
+	if alarm.Trigger.MetricName != "" {
+		message.Fields = append(message.Fields, notify.Field{Name: "metric", Value: alarm.Trigger.Namespace + "/" + alarm.Trigger.MetricName})
+	}
+	return message
+}
+
+// alarmMessage ALARM 為 Error、INSUFFICIENT_DATA 為 Warning、OK 視為 Resolved
+func alarmMessage(name, arn, state, previous, reason, description string) notify.Message {
+	message := notify.Message{
+		Title: name,
+		Text:  reason,
+		Key:   arn,
+		Fields: []notify.Field{
+			{Name: "state", Value: previous + " → " + state},
+		},
+	}
+	if message.Key == "" {
+		message.Key = name
+	}
+	if description != "" {
+		message.Text = description + "\n" + reason
+	}
+
+	switch state {
+	case "ALARM":
+		message.Severity = notify.SeverityError
+	case "OK":
+		message.Severity = notify.SeverityInfo
+		message.Status = notify.StatusResolved
+	default:
+		message.Severity = notify.SeverityWarning
+	}
+
+	// arn:aws:cloudwatch:<region>:<account>:alarm:<name>
+	if parts := strings.SplitN(arn, ":", 7); len(parts) == 7 {
+		region := parts[3]
+		message.URL = fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s", region, region, url.PathEscape(name))
+		message.Fields = append(message.Fields, notify.Field{Name: "region", Value: region})
+	}
+	return message
+}
+
+func topicName(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+// formatDetail 以 key: value 列出第一層欄位，巢狀內容保留 JSON
+func formatDetail(detail map[string]interface{}) string {
+	keys := make([]string, 0, len(detail))
+	for key := range detail {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, key := range keys {
+		value := detail[key]
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			data, _ := json.Marshal(value)
+			lines = append(lines, fmt.Sprintf("%s: %s", key, data))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %v", key, value))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,12 @@
+module github.com/gps-gaming/notify-go/notifylogrus
+
+go 1.22.1
+
+require (
+	github.com/gps-gaming/notify-go v0.0.0
+	github.com/sirupsen/logrus v1.9.3
+)
+
+require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+
+replace github.com/gps-gaming/notify-go => ../
@@ -0,0 +1,123 @@
+// Package notifylogrus 將 logrus 的 Warn/Error/Fatal 紀錄透過 notify 送出
+package notifylogrus
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+	"github.com/sirupsen/logrus"
+)
+
+type Option func(*Hook)
+
+// WithLevels 觸發通知的等級，預設 Warn、Error、Fatal、Panic
+func WithLevels(levels ...logrus.Level) Option {
+	return func(h *Hook) { h.levels = levels }
+}
+
+// Route 指定等級改送到另一組通道，例如 Fatal 另外發 PagerDuty
+func Route(level logrus.Level, n *notify.Notify) Option {
+	return func(h *Hook) { h.routes[level] = n }
+}
+
+// WithThrottle 相同訊息在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(h *Hook) { h.throttle = notify.NewThrottle(window, burst) }
+}
+
+type Hook struct {
+	notify   *notify.Notify
+	routes   map[logrus.Level]*notify.Notify
+	levels   []logrus.Level
+	throttle *notify.Throttle
+}
+
+func New(n *notify.Notify, opts ...Option) *Hook {
+	h := &Hook{
+		notify:   n,
+		routes:   map[logrus.Level]*notify.Notify{},
+		levels:   []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel},
+		throttle: notify.NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire Warn/Error 於背景送出避免阻塞 log；Fatal/Panic 同步送出，確保程式結束前送達
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	if !h.throttle.Allow(entry.Level.String() + "\x00" + entry.Message) {
+		return nil
+	}
+
+	n := h.notify
+	if routed, ok := h.routes[entry.Level]; ok {
+		n = routed
+	}
+	if n == nil {
+		return nil
+	}
+
+	message := notify.Message{
+		Title:    entry.Message,
+		Severity: severity(entry.Level),
+		Fields:   fields(entry),
+		Key:      entry.Message,
+	}
+	if dropped := h.throttle.Dropped(); dropped > 0 {
+		message.Text = fmt.Sprintf("(%d similar messages suppressed)", dropped)
+	}
+
+	if entry.Level <= logrus.FatalLevel {
+		return n.Send(message)
+	}
+	go n.Send(message)
+	return nil
+}
+
+func severity(level logrus.Level) notify.Severity {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return notify.SeverityCritical
+	case logrus.ErrorLevel:
+		return notify.SeverityError
+	case logrus.WarnLevel:
+		return notify.SeverityWarning
+	default:
+		return notify.SeverityInfo
+	}
+}
+
+func fields(entry *logrus.Entry) []notify.Field {
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out []notify.Field
+	for _, key := range keys {
+		var value string
+		switch v := entry.Data[key].(type) {
+		case error:
+			value = v.Error()
+		case string:
+			value = v
+		default:
+			value = fmt.Sprint(v)
+		}
+		out = append(out, notify.Field{Name: key, Value: value})
+	}
+	if entry.HasCaller() {
+		out = append(out, notify.Field{Name: "caller", Value: entry.Caller.File + ":" + strconv.Itoa(entry.Caller.Line)})
+	}
+	return out
+}
@@ -0,0 +1,270 @@
+package notifyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+type HandlerOption func(*handler)
+
+// HandlerToken 要求 Authorization: Bearer <token> 或 X-Notify-Token header；
+// 未設定 token 時一律拒絕，除非明確使用 HandlerAllowUnauthenticated
+func HandlerToken(token string) HandlerOption {
+	return func(h *handler) { h.token = token }
+}
+
+// HandlerAllowUnauthenticated 未設定 token 時仍接受請求，僅適用於已由其他方式限制存取的環境
+func HandlerAllowUnauthenticated() HandlerOption {
+	return func(h *handler) { h.allowUnauthenticated = true }
+}
+
+// HandlerPerAlert 每個 alert 各自送出 (以 fingerprint 作為 Key)，預設整個 group 合併為一則
+func HandlerPerAlert() HandlerOption {
+	return func(h *handler) { h.perAlert = true }
+}
+
+type handler struct {
+	notify               *notify.Notify
+	token                string
+	allowUnauthenticated bool
+	perAlert             bool
+}
+
+// authorize 與 Server 相同採 fail-closed，驗證失敗時寫入錯誤回應並回傳 false
+func (h *handler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.token == "" {
+		if !h.allowUnauthenticated {
+			writeError(w, http.StatusInternalServerError, "token not configured, use HandlerToken or HandlerAllowUnauthenticated")
+		}
+		return h.allowUnauthenticated
+	}
+	if !tokenMatches(r, h.token) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return false
+	}
+	return true
+}
+
+// AlertmanagerWebhook Prometheus Alertmanager webhook payload (version 4)
+type AlertmanagerWebhook struct {
+	Receiver          string              `json:"receiver"`
+	Status            string              `json:"status"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	GroupKey          string              `json:"groupKey"`
+	TruncatedAlerts   int                 `json:"truncatedAlerts"`
+}
+
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Alertmanager 接收 Alertmanager webhook 並透過 n 送出，取代各平台各自的 receiver 設定：
+//
+//	receivers:
+//	- name: notify
+//	  webhook_configs:
+//	  - url: http://notify-gateway:8080/alertmanager
+func Alertmanager(n *notify.Notify, opts ...HandlerOption) http.Handler {
+	h := &handler{notify: n}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return http.HandlerFunc(h.serveAlertmanager)
+}
+
+func (h *handler) serveAlertmanager(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var payload AlertmanagerWebhook
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<20)).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+
+	var messages []notify.Message
+	if h.perAlert {
+		for _, alert := range payload.Alerts {
+			messages = append(messages, alertMessage(alert))
+		}
+	} else {
+		messages = append(messages, groupMessage(payload, nil))
+	}
+
+	// 回傳 5xx 讓 Alertmanager 重送
+	for _, message := range messages {
+		if err := h.notify.Send(message); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"sent": len(messages)})
+}
+
+// groupMessage detail 為各 alert 額外附加的說明 (例如 Grafana 的數值)，可為 nil
+func groupMessage(payload AlertmanagerWebhook, detail func(i int) string) notify.Message {
+	var firing, resolved []int
+	for i, alert := range payload.Alerts {
+		if alert.Status == "resolved" {
+			resolved = append(resolved, i)
+		} else {
+			firing = append(firing, i)
+		}
+	}
+
+	name := payload.CommonLabels["alertname"]
+	if name == "" {
+		name = payload.GroupLabels["alertname"]
+	}
+	if name == "" {
+		name = payload.Receiver
+	}
+
+	message := notify.Message{
+		Severity: labelSeverity(payload.CommonLabels),
+		Key:      payload.GroupKey,
+		URL:      payload.ExternalURL,
+	}
+	if len(firing) == 0 {
+		message.Status = notify.StatusResolved
+		message.Title = fmt.Sprintf("%s (%d resolved)", name, len(resolved))
+	} else {
+		message.Title = fmt.Sprintf("%s (%d firing)", name, len(firing))
+	}
+
+	var lines []string
+	if summary := firstOf(payload.CommonAnnotations, "summary", "description"); summary != "" {
+		lines = append(lines, summary)
+	}
+	for _, section := range []struct {
+		name   string
+		alerts []int
+	}{{"Firing", firing}, {"Resolved", resolved}} {
+		if len(section.alerts) == 0 {
+			continue
+		}
+		// 只有一種狀態時不需要分段標題
+		if len(firing) > 0 && len(resolved) > 0 {
+			lines = append(lines, "", section.name+":")
+		}
+		for _, i := range section.alerts {
+			line := alertLine(payload.Alerts[i], payload.CommonLabels, payload.CommonAnnotations)
+			if detail != nil {
+				if extra := detail(i); extra != "" {
+					line += " (" + extra + ")"
+				}
+			}
+			lines = append(lines, "• "+line)
+		}
+	}
+	if payload.TruncatedAlerts > 0 {
+		lines = append(lines, fmt.Sprintf("… and %d more", payload.TruncatedAlerts))
+	}
+	message.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	for _, name := range sortedKeys(payload.CommonLabels) {
+		if name == "alertname" || name == "severity" {
+			continue
+		}
+		message.Fields = append(message.Fields, notify.Field{Name: name, Value: payload.CommonLabels[name]})
+	}
+	return message
+}
+
+func alertMessage(alert AlertmanagerAlert) notify.Message {
+	message := notify.Message{
+		Title:    alert.Labels["alertname"],
+		Text:     firstOf(alert.Annotations, "description", "summary", "message"),
+		Severity: labelSeverity(alert.Labels),
+		URL:      alert.GeneratorURL,
+		Key:      alert.Fingerprint,
+	}
+	if summary := alert.Annotations["summary"]; summary != "" && summary != message.Text {
+		message.Title = strings.TrimSpace(message.Title + ": " + summary)
+	}
+	if alert.Status == "resolved" {
+		message.Status = notify.StatusResolved
+	}
+	for _, name := range sortedKeys(alert.Labels) {
+		if name == "alertname" || name == "severity" {
+			continue
+		}
+		message.Fields = append(message.Fields, notify.Field{Name: name, Value: alert.Labels[name]})
+	}
+	return message
+}
+
+// alertLine 只列出與整組不同的 labels，避免每行重複相同資訊
+func alertLine(alert AlertmanagerAlert, common, commonAnnotations map[string]string) string {
+	text := firstOf(alert.Annotations, "summary", "description", "message")
+	if text == commonAnnotations["summary"] || text == commonAnnotations["description"] {
+		text = ""
+	}
+
+	var labels []string
+	for _, name := range sortedKeys(alert.Labels) {
+		if _, ok := common[name]; ok {
+			continue
+		}
+		labels = append(labels, name+"="+alert.Labels[name])
+	}
+
+	parts := []string{}
+	if text != "" {
+		parts = append(parts, text)
+	}
+	if len(labels) > 0 {
+		parts = append(parts, strings.Join(labels, " "))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, alert.Labels["alertname"])
+	}
+	return strings.Join(parts, " — ")
+}
+
+func labelSeverity(labels map[string]string) notify.Severity {
+	if severity, err := notify.ParseSeverity(labels["severity"]); err == nil && labels["severity"] != "" {
+		return severity
+	}
+	// 未標示 severity 的 alert 視為 warning
+	return notify.SeverityWarning
+}
+
+func firstOf(values map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := values[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,201 @@
+package notifyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+// CloudEvent CloudEvents 1.0 事件，Data 為原始 JSON
+type CloudEvent struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            string            `json:"time,omitempty"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage   `json:"data,omitempty"`
+	Extensions      map[string]string `json:"-"`
+}
+
+// CloudEvents 以 HTTP binding 接收事件 (binary、structured 與 batch mode) 並轉為通知；
+// data 為 {"message", "title", "severity", ...} (同 POST /notify) 時直接對應，其餘以事件 type 為標題、data 為內容
+func CloudEvents(n *notify.Notify, opts ...HandlerOption) http.Handler {
+	h := &handler{notify: n}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return http.HandlerFunc(h.serveCloudEvents)
+}
+
+func (h *handler) serveCloudEvents(w http.ResponseWriter, r *http.Request) {
+	// CloudEvents webhook 的 abuse protection 驗證
+	if r.Method == http.MethodOptions {
+		if origin := r.Header.Get("WebHook-Request-Origin"); origin != "" {
+			w.Header().Set("WebHook-Allowed-Origin", origin)
+			w.Header().Set("WebHook-Allowed-Rate", "*")
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.authorize(w, r) {
+		return
+	}
+
+	events, err := readCloudEvents(r, http.MaxBytesReader(w, r.Body, 4<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, event := range events {
+		if err := h.notify.Send(cloudEventMessage(event)); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func readCloudEvents(r *http.Request, body io.Reader) ([]CloudEvent, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/cloudevents+json":
+		event, err := parseStructuredEvent(data)
+		if err != nil {
+			return nil, err
+		}
+		return []CloudEvent{event}, nil
+
+	case "application/cloudevents-batch+json":
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("invalid cloudevents batch: %v", err)
+		}
+		var events []CloudEvent
+		for _, item := range raw {
+			event, err := parseStructuredEvent(item)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	}
+
+	// binary mode：屬性放在 ce-* header
+	event := CloudEvent{
+		SpecVersion:     r.Header.Get("ce-specversion"),
+		ID:              r.Header.Get("ce-id"),
+		Source:          r.Header.Get("ce-source"),
+		Type:            r.Header.Get("ce-type"),
+		Subject:         r.Header.Get("ce-subject"),
+		Time:            r.Header.Get("ce-time"),
+		DataContentType: r.Header.Get("Content-Type"),
+		Extensions:      map[string]string{},
+	}
+	if event.SpecVersion == "" || event.Type == "" {
+		return nil, fmt.Errorf("missing ce-specversion or ce-type header")
+	}
+	for key, values := range r.Header {
+		name := strings.ToLower(key)
+		if !strings.HasPrefix(name, "ce-") {
+			continue
+		}
+		switch name = strings.TrimPrefix(name, "ce-"); name {
+		case "specversion", "id", "source", "type", "subject", "time":
+		default:
+			event.Extensions[name] = values[0]
+		}
+	}
+	if json.Valid(data) {
+		event.Data = data
+	} else if len(data) > 0 {
+		// 非 JSON 內容轉為 JSON 字串保存
+		event.Data, _ = json.Marshal(string(data))
+	}
+	return []CloudEvent{event}, nil
+}
+
+func parseStructuredEvent(data []byte) (CloudEvent, error) {
+	var event CloudEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return event, fmt.Errorf("invalid cloudevent: %v", err)
+	}
+	if event.SpecVersion == "" || event.Type == "" {
+		return event, fmt.Errorf("cloudevent missing specversion or type")
+	}
+
+	var attributes map[string]interface{}
+	json.Unmarshal(data, &attributes)
+	event.Extensions = map[string]string{}
+	for key, value := range attributes {
+		switch key {
+		case "specversion", "id", "source", "type", "subject", "time", "datacontenttype", "data", "data_base64", "dataschema":
+		default:
+			event.Extensions[key] = fmt.Sprint(value)
+		}
+	}
+	return event, nil
+}
+
+func cloudEventMessage(event CloudEvent) notify.Message {
+	var req Request
+	if json.Unmarshal(event.Data, &req) == nil && (req.Message != "" || req.Title != "") {
+		if message, err := req.message(); err == nil {
+			if message.Key == "" {
+				message.Key = event.Subject
+			}
+			return message
+		}
+	}
+
+	message := notify.Message{
+		Title: event.Type,
+		Key:   event.Subject,
+	}
+	if event.Subject != "" {
+		message.Title += ": " + event.Subject
+	}
+
+	var text string
+	if json.Unmarshal(event.Data, &text) != nil {
+		var indented strings.Builder
+		var value interface{}
+		if json.Unmarshal(event.Data, &value) == nil {
+			enc := json.NewEncoder(&indented)
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false)
+			enc.Encode(value)
+		}
+		text = strings.TrimSpace(indented.String())
+	}
+	message.Text = text
+
+	if severity, ok := event.Extensions["severity"]; ok {
+		message.Severity, _ = notify.ParseSeverity(severity)
+	}
+	if status, ok := event.Extensions["status"]; ok {
+		message.Status, _ = notify.ParseStatus(status)
+	}
+	message.Fields = append(message.Fields, notify.Field{Name: "source", Value: event.Source})
+	if event.ID != "" {
+		message.Fields = append(message.Fields, notify.Field{Name: "id", Value: event.ID})
+	}
+	return message
+}
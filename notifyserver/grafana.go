@@ -0,0 +1,169 @@
+package notifyserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+// GrafanaWebhook Grafana unified alerting 的 webhook payload，格式延伸自 Alertmanager
+type GrafanaWebhook struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Title             string            `json:"title"`
+	State             string            `json:"state"`
+	Message           string            `json:"message"`
+}
+
+type GrafanaAlert struct {
+	AlertmanagerAlert
+	Values       map[string]float64 `json:"values"`
+	ValueString  string             `json:"valueString"`
+	DashboardURL string             `json:"dashboardURL"`
+	PanelURL     string             `json:"panelURL"`
+	SilenceURL   string             `json:"silenceURL"`
+	ImageURL     string             `json:"imageURL"`
+}
+
+// Grafana 接收 Grafana alerting 的 webhook contact point，數值與 dashboard / panel 連結
+// 轉為 Message 的欄位與連結，由各 provider 轉成原生格式 (embed、flex message、HTML 等)
+func Grafana(n *notify.Notify, opts ...HandlerOption) http.Handler {
+	h := &handler{notify: n}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return http.HandlerFunc(h.serveGrafana)
+}
+
+func (h *handler) serveGrafana(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var payload GrafanaWebhook
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<20)).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+
+	var messages []notify.Message
+	if h.perAlert {
+		for _, alert := range payload.Alerts {
+			messages = append(messages, grafanaAlertMessage(alert))
+		}
+	} else {
+		messages = append(messages, grafanaGroupMessage(payload))
+	}
+
+	for _, message := range messages {
+		if err := h.notify.Send(message); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"sent": len(messages)})
+}
+
+func grafanaGroupMessage(payload GrafanaWebhook) notify.Message {
+	am := AlertmanagerWebhook{
+		Receiver:          payload.Receiver,
+		Status:            payload.Status,
+		GroupLabels:       payload.GroupLabels,
+		CommonLabels:      payload.CommonLabels,
+		CommonAnnotations: payload.CommonAnnotations,
+		ExternalURL:       payload.ExternalURL,
+		GroupKey:          payload.GroupKey,
+		TruncatedAlerts:   payload.TruncatedAlerts,
+	}
+	for _, alert := range payload.Alerts {
+		am.Alerts = append(am.Alerts, alert.AlertmanagerAlert)
+	}
+
+	message := groupMessage(am, func(i int) string {
+		return grafanaValues(payload.Alerts[i])
+	})
+
+	// 整組共用同一個 dashboard / panel 時作為主要連結
+	var dashboard, panel string
+	for i, alert := range payload.Alerts {
+		if i == 0 {
+			dashboard, panel = alert.DashboardURL, alert.PanelURL
+			continue
+		}
+		if alert.DashboardURL != dashboard {
+			dashboard = ""
+		}
+		if alert.PanelURL != panel {
+			panel = ""
+		}
+	}
+	switch {
+	case panel != "":
+		message.URL = panel
+	case dashboard != "":
+		message.URL = dashboard
+	}
+	if dashboard != "" && dashboard != message.URL {
+		message.Fields = append(message.Fields, notify.Field{Name: "Dashboard", Value: dashboard})
+	}
+	return message
+}
+
+func grafanaAlertMessage(alert GrafanaAlert) notify.Message {
+	message := alertMessage(alert.AlertmanagerAlert)
+	if values := grafanaValues(alert); values != "" {
+		message.Fields = append(message.Fields, notify.Field{Name: "Values", Value: values})
+	}
+
+	switch {
+	case alert.PanelURL != "":
+		message.URL = alert.PanelURL
+	case alert.DashboardURL != "":
+		message.URL = alert.DashboardURL
+	}
+	if alert.DashboardURL != "" && alert.DashboardURL != message.URL {
+		message.Fields = append(message.Fields, notify.Field{Name: "Dashboard", Value: alert.DashboardURL})
+	}
+	if alert.ImageURL != "" {
+		message.Fields = append(message.Fields, notify.Field{Name: "Image", Value: alert.ImageURL})
+	}
+	if alert.SilenceURL != "" && message.Status != notify.StatusResolved {
+		message.Fields = append(message.Fields, notify.Field{Name: "Silence", Value: alert.SilenceURL})
+	}
+	return message
+}
+
+// grafanaValues 例如 "A=93.2 B=1"，舊版 Grafana 沒有 values 時使用 valueString
+func grafanaValues(alert GrafanaAlert) string {
+	if len(alert.Values) == 0 {
+		return strings.TrimSpace(alert.ValueString)
+	}
+
+	names := make([]string, 0, len(alert.Values))
+	for name := range alert.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		parts = append(parts, name+"="+strconv.FormatFloat(alert.Values[name], 'g', 6, 64))
+	}
+	return strings.Join(parts, " ")
+}
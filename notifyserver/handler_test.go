@@ -0,0 +1,66 @@
+package notifyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+func TestHandlerAuth(t *testing.T) {
+	handlers := []struct {
+		name string
+		new  func(opts ...HandlerOption) http.Handler
+		path string
+		body string
+		ok   int
+	}{
+		{"alertmanager", func(opts ...HandlerOption) http.Handler { return Alertmanager(notify.New(), opts...) },
+			"/alertmanager", `{"status":"firing","alerts":[{"status":"firing","labels":{"alertname":"DiskFull"}}]}`, http.StatusOK},
+		{"grafana", func(opts ...HandlerOption) http.Handler { return Grafana(notify.New(), opts...) },
+			"/grafana", `{"status":"firing","alerts":[{"status":"firing","labels":{"alertname":"DiskFull"}}]}`, http.StatusOK},
+		{"cloudevents", func(opts ...HandlerOption) http.Handler { return CloudEvents(notify.New(), opts...) },
+			"/events", `{"specversion":"1.0","id":"1","source":"/test","type":"disk.full","data":{"message":"disk full"}}`, http.StatusAccepted},
+		{"relay", func(opts ...HandlerOption) http.Handler {
+			return Relay(notify.New(), map[string]RelaySource{"shop": {Title: "order {{.Body.id}}"}}, opts...)
+		}, "/hooks/shop", `{"id":1}`, http.StatusAccepted},
+	}
+	tests := []struct {
+		name   string
+		opts   []HandlerOption
+		header string
+		want   int // 0 表示通過驗證，依 handler 回傳成功狀態
+	}{
+		{"no token configured", nil, "", http.StatusInternalServerError},
+		{"no token configured with header", nil, "Bearer anything", http.StatusInternalServerError},
+		{"missing token", []HandlerOption{HandlerToken("s3cret")}, "", http.StatusUnauthorized},
+		{"wrong token", []HandlerOption{HandlerToken("s3cret")}, "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", []HandlerOption{HandlerToken("s3cret")}, "Bearer s3cret", 0},
+		{"allow unauthenticated", []HandlerOption{HandlerAllowUnauthenticated()}, "", 0},
+	}
+	for _, h := range handlers {
+		for _, tt := range tests {
+			t.Run(h.name+"/"+tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, h.path, strings.NewReader(h.body))
+				req.Header.Set("Content-Type", "application/json")
+				if h.name == "cloudevents" {
+					req.Header.Set("Content-Type", "application/cloudevents+json")
+				}
+				if tt.header != "" {
+					req.Header.Set("Authorization", tt.header)
+				}
+				rec := httptest.NewRecorder()
+				h.new(tt.opts...).ServeHTTP(rec, req)
+				want := tt.want
+				if want == 0 {
+					want = h.ok
+				}
+				if rec.Code != want {
+					t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+				}
+			})
+		}
+	}
+}
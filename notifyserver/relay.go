@@ -0,0 +1,337 @@
+package notifyserver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+// RelaySource 一個 webhook 來源的轉換設定；各欄位為 text/template，資料為 RelayEvent，
+// 例如 Title: "{{.Body.repository.full_name}}"。Text 留空時送出排版後的 JSON
+type RelaySource struct {
+	Title    string
+	Text     string
+	Severity string
+	Status   string
+	Key      string
+	URL      string
+	// Filter 結果為 "false" 或空字串時略過此事件
+	Filter string
+
+	// EventHeader 事件名稱所在的 header，EventField 為 body 中的欄位 (以 "." 分隔)
+	EventHeader string
+	EventField  string
+
+	// Verify 驗證請求簽章；設定時不再檢查 HandlerToken，未設定時必須使用 HandlerToken
+	Verify func(r *http.Request, body []byte) error
+	// Notify 此來源改送到另一組通道，預設使用 Relay 的 n
+	Notify *notify.Notify
+}
+
+// RelayEvent 模板的資料
+type RelayEvent struct {
+	Source string
+	Event  string
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// GitHubSource GitHub webhook (push、pull_request、issues、workflow_run、release 等)，
+// secret 為 webhook 設定的 secret，留空時需改以 HandlerToken 驗證
+func GitHubSource(secret string) RelaySource {
+	source := RelaySource{
+		EventHeader: "X-GitHub-Event",
+		Title:       `{{.Event}}{{with .Body.action}} {{.}}{{end}}: {{get .Body "repository.full_name"}}`,
+		Text: `{{if eq .Event "push"}}{{.Body.pusher.name}} pushed {{len .Body.commits}} commit(s) to {{.Body.ref}}{{range .Body.commits}}
+- {{truncate 80 .message}}{{end}}
+{{- else if eq .Event "pull_request"}}#{{.Body.pull_request.number}} {{.Body.pull_request.title}} by {{.Body.pull_request.user.login}}
+{{- else if eq .Event "issues"}}#{{.Body.issue.number}} {{.Body.issue.title}} by {{.Body.issue.user.login}}
+{{- else if eq .Event "issue_comment"}}{{.Body.comment.user.login}} on #{{.Body.issue.number}}: {{truncate 300 .Body.comment.body}}
+{{- else if eq .Event "workflow_run"}}{{.Body.workflow_run.name}} {{get .Body "workflow_run.conclusion"}} on {{.Body.workflow_run.head_branch}}
+{{- else if eq .Event "release"}}{{.Body.release.tag_name}} {{.Body.release.name}}
+{{- else}}{{get .Body "sender.login"}}{{end}}`,
+		Severity: `{{if eq (get .Body "workflow_run.conclusion") "failure"}}error{{end}}`,
+		Key:      `github/{{get .Body "repository.full_name"}}/{{.Event}}`,
+		URL: `{{or (get .Body "compare") (get .Body "pull_request.html_url") (get .Body "issue.html_url")
+			(get .Body "workflow_run.html_url") (get .Body "release.html_url") (get .Body "repository.html_url")}}`,
+		// 建立 webhook 時的 ping 不需轉發
+		Filter: `{{ne .Event "ping"}}`,
+	}
+	if secret != "" {
+		source.Verify = func(r *http.Request, body []byte) error {
+			signature := strings.TrimPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+			expected, err := hex.DecodeString(signature)
+			if err != nil {
+				return errors.New("invalid X-Hub-Signature-256")
+			}
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(body)
+			if !hmac.Equal(mac.Sum(nil), expected) {
+				return errors.New("signature mismatch")
+			}
+			return nil
+		}
+	}
+	return source
+}
+
+// StripeSource Stripe webhook，secret 為 endpoint 的 signing secret (whsec_...)，留空時需改以 HandlerToken 驗證
+func StripeSource(secret string) RelaySource {
+	source := RelaySource{
+		EventField: "type",
+		Title:      `Stripe {{.Event}}`,
+		Text: `{{with .Body.data.object}}{{.id}}{{with .amount}} · {{.}}{{end}}{{with .currency}} {{upper .}}{{end}}
+{{- with .customer_email}} · {{.}}{{end}}{{with .failure_message}}
+{{.}}{{end}}{{with .last_payment_error}}
+{{.message}}{{end}}{{end}}`,
+		Severity: `{{if or (hasSuffix .Event "failed") (hasPrefix .Event "charge.dispute") (hasSuffix .Event "payment_action_required")}}error{{end}}`,
+		Key:      `stripe/{{get .Body "data.object.id"}}`,
+	}
+	if secret != "" {
+		source.Verify = func(r *http.Request, body []byte) error {
+			return notify.VerifyWebhookSignature(secret, r.Header.Get("Stripe-Signature"), body, 5*time.Minute)
+		}
+	}
+	return source
+}
+
+var relayFuncs = template.FuncMap{
+	// get 以 "." 分隔的路徑取值，中間任一層不存在時回傳空字串
+	"get": func(body map[string]interface{}, path string) string {
+		if value := lookup(body, path); value != nil {
+			return fmt.Sprint(value)
+		}
+		return ""
+	},
+	"truncate": func(n int, v interface{}) string {
+		s := fmt.Sprint(v)
+		if r := []rune(s); len(r) > n {
+			return string(r[:n]) + "…"
+		}
+		return s
+	},
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+type relaySource struct {
+	RelaySource
+	templates map[string]*template.Template
+	err       error
+}
+
+// Relay 通用的 webhook 轉發端點，以路徑最後一段選擇來源並用模板轉成通知：
+//
+//	http.Handle("/hooks/", notifyserver.Relay(n, map[string]notifyserver.RelaySource{
+//		"github": notifyserver.GitHubSource(secret),
+//	}))
+func Relay(n *notify.Notify, sources map[string]RelaySource, opts ...HandlerOption) http.Handler {
+	h := &handler{notify: n}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	compiled := map[string]*relaySource{}
+	for name, source := range sources {
+		rs := &relaySource{RelaySource: source, templates: map[string]*template.Template{}}
+		if source.Verify == nil && h.token == "" && !h.allowUnauthenticated {
+			rs.err = fmt.Errorf("source %s has no signing secret, use HandlerToken or HandlerAllowUnauthenticated", name)
+			compiled[name] = rs
+			continue
+		}
+		for field, text := range map[string]string{
+			"title": source.Title, "text": source.Text, "severity": source.Severity,
+			"status": source.Status, "key": source.Key, "url": source.URL, "filter": source.Filter,
+		} {
+			if text == "" {
+				continue
+			}
+			t, err := template.New(name + "." + field).Funcs(relayFuncs).Parse(text)
+			if err != nil {
+				rs.err = fmt.Errorf("invalid %s template for %s: %v", field, name, err)
+				break
+			}
+			rs.templates[field] = t
+		}
+		compiled[name] = rs
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		name := path.Base(r.URL.Path)
+		source, ok := compiled[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("unknown source %q", name))
+			return
+		}
+		if source.err != nil {
+			writeError(w, http.StatusInternalServerError, source.err.Error())
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 4<<20))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "failed to read body")
+			return
+		}
+		if source.Verify != nil {
+			if err := source.Verify(r, body); err != nil {
+				writeError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+		} else if !h.authorize(w, r) {
+			return
+		}
+
+		event, err := relayEvent(name, source.RelaySource, r, body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		message, skip, err := source.message(event)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if skip {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		target := h.notify
+		if source.Notify != nil {
+			target = source.Notify
+		}
+		if err := target.Send(message); err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func relayEvent(name string, source RelaySource, r *http.Request, body []byte) (RelayEvent, error) {
+	event := RelayEvent{Source: name, Header: r.Header}
+
+	// GitHub 可設定以 form 送出，JSON 放在 payload 欄位
+	data := body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err := r.ParseForm(); err != nil {
+			return event, fmt.Errorf("invalid form body: %v", err)
+		}
+		data = []byte(r.PostForm.Get("payload"))
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return event, fmt.Errorf("invalid JSON body: %v", err)
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		event.Body = object
+	} else {
+		event.Body = map[string]interface{}{"value": value}
+	}
+
+	if source.EventHeader != "" {
+		event.Event = r.Header.Get(source.EventHeader)
+	}
+	if source.EventField != "" {
+		if value := lookup(event.Body, source.EventField); value != nil {
+			event.Event = fmt.Sprint(value)
+		}
+	}
+	return event, nil
+}
+
+func lookup(body map[string]interface{}, path string) interface{} {
+	var current interface{} = body
+	for _, part := range strings.Split(path, ".") {
+		object, _ := current.(map[string]interface{})
+		current = object[part]
+	}
+	return current
+}
+
+func (s *relaySource) render(field string, event RelayEvent) (string, error) {
+	t, ok := s.templates[field]
+	if !ok {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	// 不存在的欄位不輸出 <no value>
+	return strings.TrimSpace(strings.ReplaceAll(buf.String(), "<no value>", "")), nil
+}
+
+func (s *relaySource) message(event RelayEvent) (notify.Message, bool, error) {
+	var message notify.Message
+	rendered := map[string]string{}
+	for field := range s.templates {
+		value, err := s.render(field, event)
+		if err != nil {
+			return message, false, err
+		}
+		rendered[field] = value
+	}
+
+	if _, ok := s.templates["filter"]; ok && (rendered["filter"] == "" || rendered["filter"] == "false") {
+		return message, true, nil
+	}
+
+	message.Title = rendered["title"]
+	message.Text = rendered["text"]
+	message.Key = rendered["key"]
+	message.URL = rendered["url"]
+	if message.Text == "" && s.Text == "" {
+		data, _ := json.MarshalIndent(event.Body, "", "  ")
+		message.Text = string(data)
+	}
+	if message.Title == "" {
+		message.Title = event.Source
+		if event.Event != "" {
+			message.Title += " " + event.Event
+		}
+	}
+	if value := rendered["severity"]; value != "" {
+		severity, err := notify.ParseSeverity(value)
+		if err != nil {
+			return message, false, err
+		}
+		message.Severity = severity
+	}
+	if value := rendered["status"]; value != "" {
+		status, err := notify.ParseStatus(value)
+		if err != nil {
+			return message, false, err
+		}
+		message.Status = status
+	}
+	return message, false, nil
+}
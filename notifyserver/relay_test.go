@@ -0,0 +1,59 @@
+package notifyserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+func TestRelayRejectsUnsignedRequests(t *testing.T) {
+	body := `{"action":"opened","repository":{"full_name":"gps-gaming/notify-go"}}`
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	valid := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		source    RelaySource
+		opts      []HandlerOption
+		signature string
+		want      int
+	}{
+		{"no secret or token", GitHubSource(""), nil, "", http.StatusInternalServerError},
+		{"no secret with token only", GitHubSource(""), []HandlerOption{HandlerToken("token")}, "", http.StatusUnauthorized},
+		{"unsigned", GitHubSource("s3cret"), nil, "", http.StatusUnauthorized},
+		{"wrong signature", GitHubSource("s3cret"), nil, "sha256=" + strings.Repeat("00", 32), http.StatusUnauthorized},
+		{"signed", GitHubSource("s3cret"), nil, valid, http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Relay(notify.New(), map[string]RelaySource{"github": tt.source}, tt.opts...)
+			req := httptest.NewRequest(http.MethodPost, "/hooks/github", strings.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "issues")
+			if tt.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRelayStripeRequiresSignature(t *testing.T) {
+	handler := Relay(notify.New(), map[string]RelaySource{"stripe": StripeSource("whsec_test")})
+	req := httptest.NewRequest(http.MethodPost, "/hooks/stripe", strings.NewReader(`{"type":"charge.failed"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+}
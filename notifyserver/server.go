@@ -0,0 +1,197 @@
+// Package notifyserver 提供 HTTP 閘道，讓非 Go 服務與 shell script 透過集中設定的通道送出通知
+package notifyserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+type Option func(*Server)
+
+// WithToken 要求 Authorization: Bearer <token> 或 X-Notify-Token header；
+// 未設定 token 時 /notify 一律拒絕，除非明確使用 AllowUnauthenticated
+func WithToken(token string) Option {
+	return func(s *Server) { s.token = token }
+}
+
+// AllowUnauthenticated 未設定 token 時仍接受請求，僅適用於已由其他方式限制存取的環境
+func AllowUnauthenticated() Option {
+	return func(s *Server) { s.allowUnauthenticated = true }
+}
+
+// WithDefaultTargets 請求未指定 targets 時使用，預設為全部
+func WithDefaultTargets(targets ...string) Option {
+	return func(s *Server) { s.defaults = targets }
+}
+
+// Server 提供 POST /notify 與 GET /healthz，targets 為名稱對應的通道組合，例如 "ops"、"oncall"
+type Server struct {
+	targets              map[string]*notify.Notify
+	token                string
+	allowUnauthenticated bool
+	defaults             []string
+	mux                  *http.ServeMux
+}
+
+func New(targets map[string]*notify.Notify, opts ...Option) *Server {
+	s := &Server{
+		targets: targets,
+		mux:     http.NewServeMux(),
+	}
+	for name := range targets {
+		s.defaults = append(s.defaults, name)
+	}
+	sort.Strings(s.defaults)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("POST /notify", s.handleNotify)
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Request POST /notify 的 JSON body
+type Request struct {
+	Message  string            `json:"message"`
+	Title    string            `json:"title,omitempty"`
+	Severity string            `json:"severity,omitempty"`
+	Status   string            `json:"status,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	URL      string            `json:"url,omitempty"`
+	Key      string            `json:"key,omitempty"`
+	Targets  []string          `json:"targets,omitempty"`
+}
+
+// Response 各 target 的結果，失敗時附上錯誤訊息
+type Response struct {
+	Sent   []string          `json:"sent"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func (s *Server) handleNotify(w http.ResponseWriter, r *http.Request) {
+	if s.token == "" && !s.allowUnauthenticated {
+		writeError(w, http.StatusInternalServerError, "token not configured, use WithToken or AllowUnauthenticated")
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	if req.Message == "" && req.Title == "" {
+		writeError(w, http.StatusBadRequest, "message is required")
+		return
+	}
+
+	message, err := req.message()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	targets := req.Targets
+	if len(targets) == 0 {
+		targets = s.defaults
+	}
+	for _, name := range targets {
+		if _, ok := s.targets[name]; !ok {
+			writeError(w, http.StatusBadRequest, "unknown target "+name)
+			return
+		}
+	}
+
+	resp := Response{Sent: []string{}}
+	for _, name := range targets {
+		if err := s.targets[name].Send(message); err != nil {
+			if resp.Errors == nil {
+				resp.Errors = map[string]string{}
+			}
+			resp.Errors[name] = err.Error()
+			continue
+		}
+		resp.Sent = append(resp.Sent, name)
+	}
+
+	status := http.StatusOK
+	if len(resp.Errors) > 0 {
+		status = http.StatusBadGateway
+	}
+	writeJSON(w, status, resp)
+}
+
+func (req Request) message() (notify.Message, error) {
+	severity, err := notify.ParseSeverity(req.Severity)
+	if err != nil {
+		return notify.Message{}, err
+	}
+	status, err := notify.ParseStatus(req.Status)
+	if err != nil {
+		return notify.Message{}, err
+	}
+
+	message := notify.Message{
+		Title:    req.Title,
+		Text:     req.Message,
+		Severity: severity,
+		Status:   status,
+		URL:      req.URL,
+		Key:      req.Key,
+	}
+	if message.Title == "" {
+		// 沒有標題時以第一行作為標題
+		message.Title, message.Text, _ = strings.Cut(req.Message, "\n")
+	}
+
+	names := make([]string, 0, len(req.Tags))
+	for name := range req.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		message.Fields = append(message.Fields, notify.Field{Name: name, Value: req.Tags[name]})
+	}
+	return message, nil
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return s.allowUnauthenticated
+	}
+	return tokenMatches(r, s.token)
+}
+
+// tokenMatches 比對 Authorization: Bearer 或 X-Notify-Token header 與 token
+func tokenMatches(r *http.Request, want string) bool {
+	token := r.Header.Get("X-Notify-Token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,53 @@
+package notifyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	notify "github.com/gps-gaming/notify-go"
+)
+
+func TestServerAuth(t *testing.T) {
+	targets := map[string]*notify.Notify{"ops": notify.New()}
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		header string
+		want   int
+	}{
+		{"no token configured", nil, "", http.StatusInternalServerError},
+		{"no token configured with header", nil, "Bearer anything", http.StatusInternalServerError},
+		{"allow unauthenticated", []Option{AllowUnauthenticated()}, "", http.StatusOK},
+		{"missing token", []Option{WithToken("s3cret")}, "", http.StatusUnauthorized},
+		{"wrong token", []Option{WithToken("s3cret")}, "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", []Option{WithToken("s3cret")}, "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(targets, tt.opts...)
+			req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(`{"message":"disk full"}`))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServerXNotifyToken(t *testing.T) {
+	srv := New(map[string]*notify.Notify{"ops": notify.New()}, WithToken("s3cret"))
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(`{"message":"disk full"}`))
+	req.Header.Set("X-Notify-Token", "s3cret")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,138 @@
+// Package notifyzap 提供 zapcore.Core，將高嚴重度的紀錄透過 notify 送出
+package notifyzap
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	notify "github.com/gps-gaming/notify-go"
+	"go.uber.org/zap/zapcore"
+)
+
+type Option func(*core)
+
+// WithLevel 觸發通知的等級，預設 Warn 以上
+func WithLevel(enabler zapcore.LevelEnabler) Option {
+	return func(c *core) { c.LevelEnabler = enabler }
+}
+
+// WithThrottle 相同訊息在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func WithThrottle(window time.Duration, burst int) Option {
+	return func(c *core) { c.throttle = notify.NewThrottle(window, burst) }
+}
+
+// NewCore 搭配 zapcore.NewTee 使用，例如
+//
+//	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+//		return zapcore.NewTee(c, notifyzap.NewCore(n))
+//	}))
+func NewCore(n *notify.Notify, opts ...Option) zapcore.Core {
+	c := &core{
+		LevelEnabler: zapcore.WarnLevel,
+		notify:       n,
+		throttle:     notify.NewThrottle(time.Minute, 10),
+		wg:           &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type core struct {
+	zapcore.LevelEnabler
+	notify   *notify.Notify
+	fields   []zapcore.Field
+	throttle *notify.Throttle
+	wg       *sync.WaitGroup
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field{}, c.fields...), fields...)
+	return &clone
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write Warn/Error 於背景送出；DPanic 以上同步送出，確保程式結束前送達
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.throttle.Allow(entry.Level.String() + "\x00" + entry.Message) {
+		return nil
+	}
+
+	message := notify.Message{
+		Title:    entry.Message,
+		Severity: severity(entry.Level),
+		Fields:   render(entry, append(append([]zapcore.Field{}, c.fields...), fields...)),
+		Key:      entry.Message,
+	}
+	if dropped := c.throttle.Dropped(); dropped > 0 {
+		message.Text = fmt.Sprintf("(%d similar messages suppressed)", dropped)
+	}
+
+	if entry.Level > zapcore.ErrorLevel {
+		return c.notify.Send(message)
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.notify.Send(message)
+	}()
+	return nil
+}
+
+// Sync 等待背景中的通知送出
+func (c *core) Sync() error {
+	c.wg.Wait()
+	return nil
+}
+
+func severity(level zapcore.Level) notify.Severity {
+	switch {
+	case level > zapcore.ErrorLevel:
+		return notify.SeverityCritical
+	case level == zapcore.ErrorLevel:
+		return notify.SeverityError
+	case level == zapcore.WarnLevel:
+		return notify.SeverityWarning
+	default:
+		return notify.SeverityInfo
+	}
+}
+
+func render(entry zapcore.Entry, fields []zapcore.Field) []notify.Field {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	keys := make([]string, 0, len(encoder.Fields))
+	for key := range encoder.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out []notify.Field
+	if entry.LoggerName != "" {
+		out = append(out, notify.Field{Name: "logger", Value: entry.LoggerName})
+	}
+	for _, key := range keys {
+		out = append(out, notify.Field{Name: key, Value: fmt.Sprint(encoder.Fields[key])})
+	}
+	if entry.Caller.Defined {
+		out = append(out, notify.Field{Name: "caller", Value: entry.Caller.TrimmedPath()})
+	}
+	if entry.Stack != "" {
+		out = append(out, notify.Field{Name: "stacktrace", Value: entry.Stack})
+	}
+	return out
+}
@@ -0,0 +1,12 @@
+module github.com/gps-gaming/notify-go/notifyzap
+
+go 1.22.1
+
+require (
+	github.com/gps-gaming/notify-go v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/gps-gaming/notify-go => ../
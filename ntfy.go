@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type NtfyOption func(*ntfy)
+
+func NtfyTitle(title string) NtfyOption {
+	return func(n *ntfy) { n.Title = title }
+}
+
+// NtfyPriority 1 (min) ~ 5 (max)，預設 3
+func NtfyPriority(priority int) NtfyOption {
+	return func(n *ntfy) { n.Priority = priority }
+}
+
+func NtfyTags(tags ...string) NtfyOption {
+	return func(n *ntfy) { n.Tags = append(n.Tags, tags...) }
+}
+
+func NtfyClick(url string) NtfyOption {
+	return func(n *ntfy) { n.Click = url }
+}
+
+// NtfyAttach 附加外部檔案連結
+func NtfyAttach(url, filename string) NtfyOption {
+	return func(n *ntfy) {
+		n.Attach = url
+		n.Filename = filename
+	}
+}
+
+func NtfyToken(token string) NtfyOption {
+	return func(n *ntfy) { n.Token = token }
+}
+
+func NtfyBasicAuth(username, password string) NtfyOption {
+	return func(n *ntfy) {
+		n.Username = username
+		n.Password = password
+	}
+}
+
+// Ntfy serverURL 留空時使用 https://ntfy.sh
+func (n *Notify) Ntfy(serverURL, topic string, opts ...NtfyOption) *Notify {
+	if serverURL == "" {
+		serverURL = "https://ntfy.sh"
+	}
+	t := &ntfy{
+		ServerURL: strings.TrimRight(serverURL, "/"),
+		Topic:     topic,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	n.add(t)
+	return n
+}
+
+type ntfy struct {
+	ServerURL string
+	Topic     string
+	Title     string
+	Priority  int
+	Tags      []string
+	Click     string
+	Attach    string
+	Filename  string
+	Token     string
+	Username  string
+	Password  string
+}
+
+func (t *ntfy) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{
+		"message": message,
+	}
+	if t.Title != "" {
+		payload["title"] = t.Title
+	}
+	if t.Priority != 0 {
+		payload["priority"] = t.Priority
+	}
+	if len(t.Tags) > 0 {
+		payload["tags"] = t.Tags
+	}
+	if t.Click != "" {
+		payload["click"] = t.Click
+	}
+	if t.Attach != "" {
+		payload["attach"] = t.Attach
+		if t.Filename != "" {
+			payload["filename"] = t.Filename
+		}
+	}
+
+	return t.SendRaw(client, payload)
+}
+
+func (t *ntfy) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["topic"]; !ok {
+		message["topic"] = t.Topic
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", t.ServerURL+"/", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if t.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.Token)
+	} else if t.Username != "" {
+		req.SetBasicAuth(t.Username, t.Password)
+	}
+
+	return request(client, req)
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type OneSignalOption func(*onesignal)
+
+func OneSignalSegments(segments ...string) OneSignalOption {
+	return func(o *onesignal) { o.Segments = append(o.Segments, segments...) }
+}
+
+func OneSignalExternalIDs(ids ...string) OneSignalOption {
+	return func(o *onesignal) { o.ExternalIDs = append(o.ExternalIDs, ids...) }
+}
+
+// OneSignal 未指定對象時送給 "Total Subscriptions" 區段
+func (n *Notify) OneSignal(appID, restAPIKey string, opts ...OneSignalOption) *Notify {
+	o := &onesignal{
+		AppID:      appID,
+		RESTAPIKey: restAPIKey,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	n.add(o)
+	return n
+}
+
+type onesignal struct {
+	AppID       string
+	RESTAPIKey  string
+	Segments    []string
+	ExternalIDs []string
+}
+
+func (o *onesignal) Send(client *http.Client, message string) error {
+	return o.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+func (o *onesignal) SendMessage(client *http.Client, message Message) error {
+	notification := map[string]interface{}{
+		"headings": map[string]string{"en": message.Title},
+		"contents": map[string]string{"en": message.Text},
+	}
+	if message.URL != "" {
+		notification["url"] = message.URL
+	}
+	if message.Key != "" {
+		// 相同 collapse_id 的通知只會顯示最新一則
+		notification["collapse_id"] = message.Key
+	}
+	if len(message.Fields) > 0 {
+		data := map[string]string{}
+		for _, field := range message.Fields {
+			data[field.Name] = field.Value
+		}
+		notification["data"] = data
+	}
+
+	return o.SendRaw(client, notification)
+}
+
+func (o *onesignal) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["app_id"]; !ok {
+		message["app_id"] = o.AppID
+	}
+
+	_, hasSegments := message["included_segments"]
+	_, hasAliases := message["include_aliases"]
+	if !hasSegments && !hasAliases {
+		if len(o.ExternalIDs) > 0 {
+			message["include_aliases"] = map[string]interface{}{"external_id": o.ExternalIDs}
+			message["target_channel"] = "push"
+		} else if len(o.Segments) > 0 {
+			message["included_segments"] = o.Segments
+		} else {
+			message["included_segments"] = []string{"Total Subscriptions"}
+		}
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.onesignal.com/notifications", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Key "+o.RESTAPIKey)
+
+	return request(client, req)
+}
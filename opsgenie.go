@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type OpsgenieOption func(*opsgenie)
+
+func OpsgenieEU() OpsgenieOption {
+	return func(o *opsgenie) { o.BaseURL = "https://api.eu.opsgenie.com" }
+}
+
+func OpsgenieTags(tags ...string) OpsgenieOption {
+	return func(o *opsgenie) { o.Tags = append(o.Tags, tags...) }
+}
+
+// OpsgenieResponder responderType 為 team、user、escalation 或 schedule
+func OpsgenieResponder(responderType, name string) OpsgenieOption {
+	return func(o *opsgenie) {
+		o.Responders = append(o.Responders, map[string]string{"type": responderType, "name": name})
+	}
+}
+
+func (n *Notify) Opsgenie(apiKey string, opts ...OpsgenieOption) *Notify {
+	o := &opsgenie{
+		APIKey:  apiKey,
+		BaseURL: "https://api.opsgenie.com",
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	n.add(o)
+	return n
+}
+
+type opsgenie struct {
+	APIKey     string
+	BaseURL    string
+	Tags       []string
+	Responders []map[string]string
+}
+
+func (o *opsgenie) Send(client *http.Client, message string) error {
+	return o.SendMessage(client, Message{
+		Title:    subjectOf(message),
+		Text:     message,
+		Severity: SeverityError,
+	})
+}
+
+// SendMessage 以 Key 作為 alias，StatusAcknowledged / StatusResolved 會確認或關閉同 alias 的告警
+func (o *opsgenie) SendMessage(client *http.Client, message Message) error {
+	if message.Key != "" && message.Status != StatusFiring {
+		action := "close"
+		if message.Status == StatusAcknowledged {
+			action = "acknowledge"
+		}
+		url := fmt.Sprintf("%s/v2/alerts/%s/%s?identifierType=alias", o.BaseURL, url.PathEscape(message.Key), action)
+		return o.post(client, url, map[string]interface{}{
+			"source": "notify-go",
+			"note":   message.Text,
+		})
+	}
+
+	title := message.Title
+	if title == "" {
+		title = subjectOf(message.Text)
+	}
+	// message 上限 130 字元
+	if r := []rune(title); len(r) > 130 {
+		title = string(r[:130])
+	}
+
+	alert := map[string]interface{}{
+		"message":     title,
+		"description": message.Text,
+		"priority":    opsgeniePriority(message.Severity),
+	}
+	if message.Key != "" {
+		alert["alias"] = message.Key
+	}
+	details := map[string]string{}
+	for _, field := range message.Fields {
+		details[field.Name] = field.Value
+	}
+	if message.URL != "" {
+		details["url"] = message.URL
+	}
+	if len(details) > 0 {
+		alert["details"] = details
+	}
+
+	return o.SendRaw(client, alert)
+}
+
+func opsgeniePriority(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "P1"
+	case SeverityError:
+		return "P2"
+	case SeverityWarning:
+		return "P3"
+	default:
+		return "P5"
+	}
+}
+
+func (o *opsgenie) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["tags"]; !ok && len(o.Tags) > 0 {
+		message["tags"] = o.Tags
+	}
+	if _, ok := message["responders"]; !ok && len(o.Responders) > 0 {
+		message["responders"] = o.Responders
+	}
+
+	return o.post(client, o.BaseURL+"/v2/alerts", message)
+}
+
+func (o *opsgenie) post(client *http.Client, url string, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.APIKey)
+
+	return request(client, req)
+}
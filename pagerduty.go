@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type PagerDutyOption func(*pagerduty)
+
+// PagerDutySource 事件來源，預設為主機名稱
+func PagerDutySource(source string) PagerDutyOption {
+	return func(p *pagerduty) { p.Source = source }
+}
+
+func PagerDutyComponent(component string) PagerDutyOption {
+	return func(p *pagerduty) { p.Component = component }
+}
+
+func PagerDutyGroup(group string) PagerDutyOption {
+	return func(p *pagerduty) { p.Group = group }
+}
+
+func (n *Notify) PagerDuty(routingKey string, opts ...PagerDutyOption) *Notify {
+	source, _ := os.Hostname()
+	p := &pagerduty{
+		RoutingKey: routingKey,
+		Source:     source,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	n.add(p)
+	return n
+}
+
+type pagerduty struct {
+	RoutingKey string
+	Source     string
+	Component  string
+	Group      string
+}
+
+func (p *pagerduty) Send(client *http.Client, message string) error {
+	return p.SendMessage(client, Message{
+		Title:    subjectOf(message),
+		Text:     message,
+		Severity: SeverityError,
+	})
+}
+
+func (p *pagerduty) SendMessage(client *http.Client, message Message) error {
+	event := map[string]interface{}{}
+	if message.Key != "" {
+		event["dedup_key"] = message.Key
+	}
+
+	switch message.Status {
+	case StatusAcknowledged:
+		event["event_action"] = "acknowledge"
+	case StatusResolved:
+		event["event_action"] = "resolve"
+	default:
+		event["event_action"] = "trigger"
+
+		summary := message.Title
+		if summary == "" {
+			summary = subjectOf(message.Text)
+		}
+		// summary 上限 1024 字元
+		if r := []rune(summary); len(r) > 1024 {
+			summary = string(r[:1024])
+		}
+
+		details := map[string]interface{}{}
+		if message.Text != "" {
+			details["text"] = message.Text
+		}
+		for _, field := range message.Fields {
+			details[field.Name] = field.Value
+		}
+
+		payload := map[string]interface{}{
+			"summary":        summary,
+			"source":         p.Source,
+			"severity":       strings.ToLower(message.Severity.String()),
+			"custom_details": details,
+		}
+		if p.Component != "" {
+			payload["component"] = p.Component
+		}
+		if p.Group != "" {
+			payload["group"] = p.Group
+		}
+		event["payload"] = payload
+
+		if message.URL != "" {
+			event["links"] = []map[string]interface{}{{"href": message.URL, "text": message.Title}}
+		}
+	}
+
+	return p.SendRaw(client, event)
+}
+
+func (p *pagerduty) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["routing_key"]; !ok {
+		message["routing_key"] = p.RoutingKey
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://events.pagerduty.com/v2/enqueue", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
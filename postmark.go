@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type PostmarkOption func(*postmark)
+
+func PostmarkSubject(subject string) PostmarkOption {
+	return func(p *postmark) { p.Subject = subject }
+}
+
+func PostmarkStream(stream string) PostmarkOption {
+	return func(p *postmark) { p.MessageStream = stream }
+}
+
+func PostmarkTag(tag string) PostmarkOption {
+	return func(p *postmark) { p.Tag = tag }
+}
+
+// PostmarkTemplate 改用樣板寄送，訊息會放在 TemplateModel 的 subject 與 message 欄位
+func PostmarkTemplate(alias string) PostmarkOption {
+	return func(p *postmark) { p.TemplateAlias = alias }
+}
+
+func (n *Notify) Postmark(serverToken, from string, to []string, opts ...PostmarkOption) *Notify {
+	p := &postmark{
+		ServerToken: serverToken,
+		From:        from,
+		To:          to,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	n.add(p)
+	return n
+}
+
+type postmark struct {
+	ServerToken   string
+	From          string
+	To            []string
+	Subject       string
+	MessageStream string
+	Tag           string
+	TemplateAlias string
+}
+
+func (p *postmark) Send(client *http.Client, message string) error {
+	subject := p.Subject
+	if subject == "" {
+		subject = subjectOf(message)
+	}
+
+	if p.TemplateAlias != "" {
+		return p.SendRaw(client, map[string]interface{}{
+			"TemplateAlias": p.TemplateAlias,
+			"TemplateModel": map[string]interface{}{
+				"subject": subject,
+				"message": message,
+			},
+		})
+	}
+
+	return p.SendRaw(client, map[string]interface{}{
+		"Subject":  subject,
+		"TextBody": message,
+	})
+}
+
+func (p *postmark) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["From"]; !ok {
+		message["From"] = p.From
+	}
+	if _, ok := message["To"]; !ok {
+		message["To"] = strings.Join(p.To, ",")
+	}
+	if _, ok := message["MessageStream"]; !ok && p.MessageStream != "" {
+		message["MessageStream"] = p.MessageStream
+	}
+	if _, ok := message["Tag"]; !ok && p.Tag != "" {
+		message["Tag"] = p.Tag
+	}
+
+	url := "https://api.postmarkapp.com/email"
+	_, hasAlias := message["TemplateAlias"]
+	_, hasID := message["TemplateId"]
+	if hasAlias || hasID {
+		url = "https://api.postmarkapp.com/email/withTemplate"
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Postmark-Server-Token", p.ServerToken)
+
+	return request(client, req)
+}
@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type PubSubOption func(*pubSub)
+
+// PubSubAttribute 加入 message attribute，可供訂閱端 filter 使用
+func PubSubAttribute(name, value string) PubSubOption {
+	return func(p *pubSub) { p.Attributes[name] = value }
+}
+
+// PubSubOrdering 以 Message.Key 作為 orderingKey，訂閱需啟用 message ordering
+func PubSubOrdering() PubSubOption {
+	return func(p *pubSub) { p.Ordering = true }
+}
+
+// PubSub topic 可為名稱 (使用 service account 的 project) 或完整的 projects/<project>/topics/<topic>
+func (n *Notify) PubSub(serviceAccountJSON []byte, topic string, opts ...PubSubOption) *Notify {
+	p := &pubSub{
+		Topic:      topic,
+		Attributes: map[string]string{},
+	}
+	p.Credentials, p.err = newGoogleCredentials(serviceAccountJSON, "https://www.googleapis.com/auth/pubsub")
+	for _, opt := range opts {
+		opt(p)
+	}
+	n.add(p)
+	return n
+}
+
+type pubSub struct {
+	Credentials *googleCredentials
+	Topic       string
+	Attributes  map[string]string
+	Ordering    bool
+
+	err error
+}
+
+func (p *pubSub) Send(client *http.Client, message string) error {
+	return p.SendRaw(client, map[string]interface{}{
+		"data":       base64.StdEncoding.EncodeToString([]byte(message)),
+		"attributes": p.attributes(nil),
+	})
+}
+
+func (p *pubSub) SendMessage(client *http.Client, message Message) error {
+	record := messageRecord(message)
+	record.Timestamp = time.Now().UTC()
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	msg := map[string]interface{}{
+		"data": base64.StdEncoding.EncodeToString(jsonData),
+		"attributes": p.attributes(map[string]string{
+			"severity": record.Severity,
+			"status":   record.Status,
+		}),
+	}
+	if p.Ordering && message.Key != "" {
+		msg["orderingKey"] = message.Key
+	}
+	return p.SendRaw(client, msg)
+}
+
+// SendRaw 傳入 PubsubMessage 物件，data 需為 base64 編碼
+func (p *pubSub) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	token, err := p.Credentials.token(client)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"messages": []interface{}{message},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	topic := p.Topic
+	if !strings.HasPrefix(topic, "projects/") {
+		topic = fmt.Sprintf("projects/%s/topics/%s", p.Credentials.ProjectID, topic)
+	}
+	url := fmt.Sprintf("https://pubsub.googleapis.com/v1/%s:publish", topic)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return request(client, req)
+}
+
+func (p *pubSub) attributes(extra map[string]string) map[string]string {
+	attributes := make(map[string]string, len(p.Attributes)+len(extra))
+	for name, value := range p.Attributes {
+		attributes[name] = value
+	}
+	for name, value := range extra {
+		attributes[name] = value
+	}
+	return attributes
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type PushbulletOption func(*pushbullet)
+
+func PushbulletTitle(title string) PushbulletOption {
+	return func(p *pushbullet) { p.Title = title }
+}
+
+// PushbulletLink 改送 link push，點擊後開啟 url
+func PushbulletLink(url string) PushbulletOption {
+	return func(p *pushbullet) { p.URL = url }
+}
+
+func PushbulletDevice(deviceIden string) PushbulletOption {
+	return func(p *pushbullet) { p.DeviceIden = deviceIden }
+}
+
+func PushbulletChannel(channelTag string) PushbulletOption {
+	return func(p *pushbullet) { p.ChannelTag = channelTag }
+}
+
+func (n *Notify) Pushbullet(accessToken string, opts ...PushbulletOption) *Notify {
+	p := &pushbullet{AccessToken: accessToken}
+	for _, opt := range opts {
+		opt(p)
+	}
+	n.add(p)
+	return n
+}
+
+type pushbullet struct {
+	AccessToken string
+	Title       string
+	URL         string
+	DeviceIden  string
+	ChannelTag  string
+}
+
+func (p *pushbullet) Send(client *http.Client, message string) error {
+	push := map[string]interface{}{
+		"type": "note",
+		"body": message,
+	}
+	if p.URL != "" {
+		push["type"] = "link"
+		push["url"] = p.URL
+	}
+	if p.Title != "" {
+		push["title"] = p.Title
+	}
+
+	return p.SendRaw(client, push)
+}
+
+func (p *pushbullet) SendRaw(client *http.Client, message map[string]interface{}) error {
+	_, hasDevice := message["device_iden"]
+	_, hasChannel := message["channel_tag"]
+	_, hasEmail := message["email"]
+	if !hasDevice && !hasChannel && !hasEmail {
+		if p.DeviceIden != "" {
+			message["device_iden"] = p.DeviceIden
+		} else if p.ChannelTag != "" {
+			message["channel_tag"] = p.ChannelTag
+		}
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.pushbullet.com/v2/pushes", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Access-Token", p.AccessToken)
+
+	return request(client, req)
+}
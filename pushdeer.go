@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type PushDeerOption func(*pushdeer)
+
+// PushDeerServer 自架時指定位址，預設 https://api2.pushdeer.com
+func PushDeerServer(serverURL string) PushDeerOption {
+	return func(p *pushdeer) { p.ServerURL = strings.TrimRight(serverURL, "/") }
+}
+
+func PushDeerMarkdown() PushDeerOption {
+	return func(p *pushdeer) { p.Type = "markdown" }
+}
+
+// PushDeerImage 訊息內容視為圖片網址
+func PushDeerImage() PushDeerOption {
+	return func(p *pushdeer) { p.Type = "image" }
+}
+
+func (n *Notify) PushDeer(pushKey string, opts ...PushDeerOption) *Notify {
+	p := &pushdeer{
+		ServerURL: "https://api2.pushdeer.com",
+		PushKey:   pushKey,
+		Type:      "text",
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	n.add(p)
+	return n
+}
+
+type pushdeer struct {
+	ServerURL string
+	PushKey   string
+	Type      string
+}
+
+func (p *pushdeer) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{
+		"type": p.Type,
+		"text": message,
+	}
+	if p.Type == "markdown" {
+		// markdown 時 text 為標題、desp 為內文
+		payload["text"] = subjectOf(message)
+		payload["desp"] = message
+	}
+
+	return p.SendRaw(client, payload)
+}
+
+func (p *pushdeer) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+	if !form.Has("pushkey") {
+		form.Set("pushkey", p.PushKey)
+	}
+
+	req, err := http.NewRequest("POST", p.ServerURL+"/message/push", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result struct {
+		Code  int    `json:"code"`
+		Error string `json:"error"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("pushdeer responded with code %d: %s", result.Code, result.Error)
+	}
+
+	return nil
+}
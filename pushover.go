@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PushoverLowest    = -2
+	PushoverLow       = -1
+	PushoverNormal    = 0
+	PushoverHigh      = 1
+	PushoverEmergency = 2
+)
+
+type PushoverOption func(*pushover)
+
+func PushoverTitle(title string) PushoverOption {
+	return func(p *pushover) { p.Title = title }
+}
+
+func PushoverPriority(priority int) PushoverOption {
+	return func(p *pushover) { p.Priority = priority }
+}
+
+func PushoverSound(sound string) PushoverOption {
+	return func(p *pushover) { p.Sound = sound }
+}
+
+func PushoverDevices(devices ...string) PushoverOption {
+	return func(p *pushover) { p.Devices = append(p.Devices, devices...) }
+}
+
+// PushoverEmergencyRetry 設為緊急優先級，每 retry 重送一次直到確認或超過 expire
+// Pushover 限制 retry 至少 30 秒、expire 最多 3 小時
+func PushoverEmergencyRetry(retry, expire time.Duration) PushoverOption {
+	return func(p *pushover) {
+		p.Priority = PushoverEmergency
+		p.Retry = retry
+		p.Expire = expire
+	}
+}
+
+func (n *Notify) Pushover(appToken, userKey string, opts ...PushoverOption) *Notify {
+	p := &pushover{
+		AppToken: appToken,
+		UserKey:  userKey,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.Priority == PushoverEmergency {
+		if p.Retry < 30*time.Second {
+			p.Retry = 30 * time.Second
+		}
+		if p.Expire <= 0 || p.Expire > 3*time.Hour {
+			p.Expire = 3 * time.Hour
+		}
+	}
+	n.add(p)
+	return n
+}
+
+type pushover struct {
+	AppToken string
+	UserKey  string
+	Title    string
+	Priority int
+	Sound    string
+	Devices  []string
+	Retry    time.Duration
+	Expire   time.Duration
+}
+
+func (p *pushover) Send(client *http.Client, message string) error {
+	form := url.Values{}
+	form.Set("message", message)
+	if p.Title != "" {
+		form.Set("title", p.Title)
+	}
+	if p.Priority != PushoverNormal {
+		form.Set("priority", strconv.Itoa(p.Priority))
+	}
+	if p.Priority == PushoverEmergency {
+		form.Set("retry", strconv.Itoa(int(p.Retry.Seconds())))
+		form.Set("expire", strconv.Itoa(int(p.Expire.Seconds())))
+	}
+	if p.Sound != "" {
+		form.Set("sound", p.Sound)
+	}
+	if len(p.Devices) > 0 {
+		form.Set("device", strings.Join(p.Devices, ","))
+	}
+
+	return p.post(client, form)
+}
+
+func (p *pushover) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+
+	return p.post(client, form)
+}
+
+func (p *pushover) post(client *http.Client, form url.Values) error {
+	form.Set("token", p.AppToken)
+	if !form.Has("user") {
+		form.Set("user", p.UserKey)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.pushover.net/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return request(client, req)
+}
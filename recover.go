@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+type RecoverOption func(*recoverer)
+
+// RecoverRepanic 通知後重新 panic，交給外層處理 (例如 http.Server 關閉連線並記錄)，預設回應 500
+func RecoverRepanic() RecoverOption {
+	return func(r *recoverer) { r.repanic = true }
+}
+
+// RecoverThrottle 相同路徑與 panic 內容在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func RecoverThrottle(window time.Duration, burst int) RecoverOption {
+	return func(r *recoverer) { r.throttle = NewThrottle(window, burst) }
+}
+
+type recoverer struct {
+	notify   *Notify
+	repanic  bool
+	throttle *Throttle
+}
+
+// Recover net/http middleware，攔截 panic 並通知 method、path 與 stack trace：
+//
+//	http.ListenAndServe(":8080", n.Recover()(mux))
+func (n *Notify) Recover(opts ...RecoverOption) func(http.Handler) http.Handler {
+	rc := &recoverer{
+		notify:   n,
+		throttle: NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusWriter{ResponseWriter: w}
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				// ErrAbortHandler 是刻意中斷回應，不屬於錯誤
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				message := PanicMessage(r, recovered, debug.Stack())
+				if rc.throttle.Allow(message.Key + "\x00" + message.Title) {
+					rc.notify.Send(message)
+				}
+
+				if rc.repanic {
+					panic(recovered)
+				}
+				if !sw.wroteHeader {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(sw, r)
+		})
+	}
+}
+
+// PanicMessage 將 HTTP 請求中的 panic 組成通知，供各框架的 middleware 共用
+func PanicMessage(r *http.Request, recovered interface{}, stack []byte) Message {
+	if len(stack) > 4000 {
+		stack = stack[:4000]
+	}
+
+	return Message{
+		Title:    fmt.Sprintf("panic: %v", recovered),
+		Text:     string(stack),
+		Severity: SeverityCritical,
+		Fields:   requestFields(r),
+		Key:      "panic/" + r.Method + " " + r.URL.Path,
+	}
+}
+
+func requestFields(r *http.Request) []Field {
+	fields := []Field{
+		{Name: "method", Value: r.Method},
+		{Name: "path", Value: r.URL.Path},
+		{Name: "client", Value: r.RemoteAddr},
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		fields[2].Value = forwarded
+	}
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		fields = append(fields, Field{Name: "request id", Value: id})
+	}
+	if agent := r.UserAgent(); agent != "" {
+		fields = append(fields, Field{Name: "user agent", Value: agent})
+	}
+	return fields
+}
+
+// statusWriter 記錄是否已送出 header，panic 時才知道能否回應 500
+type statusWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Unwrap 讓 http.ResponseController 能取得原本的 Flusher/Hijacker
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
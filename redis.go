@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RedisOption func(*redis)
+
+// RedisStream 改用 XADD 寫入 stream，maxLen 大於 0 時以 MAXLEN ~ 限制長度
+func RedisStream(maxLen int) RedisOption {
+	return func(r *redis) {
+		r.Stream = true
+		r.MaxLen = maxLen
+	}
+}
+
+func RedisTLS(config *tls.Config) RedisOption {
+	return func(r *redis) { r.TLSConfig = config }
+}
+
+// Redis 預設 PUBLISH 到 channel，redisURL 例如 redis://:password@localhost:6379/0 或 rediss:// (TLS)
+func (n *Notify) Redis(redisURL, channel string, opts ...RedisOption) *Notify {
+	r := &redis{
+		URL:     redisURL,
+		Channel: channel,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	n.add(r)
+	return n
+}
+
+type redis struct {
+	URL       string
+	Channel   string
+	Stream    bool
+	MaxLen    int
+	TLSConfig *tls.Config
+}
+
+func (r *redis) Send(client *http.Client, message string) error {
+	return r.SendMessage(client, Message{Text: message})
+}
+
+func (r *redis) SendMessage(_ *http.Client, message Message) error {
+	record := messageRecord(message)
+	record.Timestamp = time.Now().UTC()
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	return r.publish(jsonData, record.Severity, message.Key)
+}
+
+func (r *redis) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	severity, _ := message["severity"].(string)
+	key, _ := message["key"].(string)
+	return r.publish(jsonData, severity, key)
+}
+
+func (r *redis) publish(payload []byte, severity, key string) error {
+	conn, reader, err := r.connect()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if !r.Stream {
+		_, err := redisDo(conn, reader, "PUBLISH", r.Channel, string(payload))
+		return err
+	}
+
+	// stream entry 另存 severity 與 key，方便 consumer 不解析 JSON 即可過濾
+	args := []string{"XADD", r.Channel}
+	if r.MaxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.Itoa(r.MaxLen))
+	}
+	args = append(args, "*", "payload", string(payload))
+	if severity != "" {
+		args = append(args, "severity", severity)
+	}
+	if key != "" {
+		args = append(args, "key", key)
+	}
+	_, err = redisDo(conn, reader, args...)
+	return err
+}
+
+func (r *redis) connect() (net.Conn, *bufio.Reader, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid redis url: %v", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	if u.Scheme == "rediss" || r.TLSConfig != nil {
+		config := r.TLSConfig
+		if config == nil {
+			config = &tls.Config{ServerName: u.Hostname()}
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, config)
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect %s: %v", host, err)
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	reader := bufio.NewReader(conn)
+
+	if u.User != nil {
+		args := []string{"AUTH"}
+		password, ok := u.User.Password()
+		if !ok {
+			password = u.User.Username()
+		} else if u.User.Username() != "" {
+			// Redis 6 ACL 使用者
+			args = append(args, u.User.Username())
+		}
+		if _, err := redisDo(conn, reader, append(args, password)...); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" && db != "0" {
+		if _, err := redisDo(conn, reader, "SELECT", db); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+
+	return conn, reader, nil
+}
+
+// redisDo 送出 RESP 指令並讀取單行或 bulk 回覆
+func redisDo(conn net.Conn, reader *bufio.Reader, args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := conn.Write([]byte(b.String())); err != nil {
+		return "", fmt.Errorf("failed to send redis command: %v", err)
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read redis reply: %v", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("empty redis reply")
+	}
+
+	switch line[0] {
+	case '-':
+		return "", fmt.Errorf("redis %s: %s", args[0], line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid redis reply: %q", line)
+		}
+		if size < 0 {
+			return "", nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return "", fmt.Errorf("failed to read redis reply: %v", err)
+		}
+		return string(data[:size]), nil
+	default:
+		return line[1:], nil
+	}
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRedis 記錄收到的 RESP 指令原文，以 reply 決定回覆
+type fakeRedis struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	transcript strings.Builder
+}
+
+func newFakeRedis(t *testing.T, reply func(command string) string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeRedis{listener: listener}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			header, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			raw := header
+			count, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+			var command string
+			for i := 0; i < count; i++ {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+				data := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, data); err != nil {
+					return
+				}
+				raw += line + string(data)
+				if i == 0 {
+					command = string(data[:size])
+				}
+			}
+
+			s.mu.Lock()
+			s.transcript.WriteString(raw)
+			s.mu.Unlock()
+			conn.Write([]byte(reply(command)))
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeRedis) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transcript.String()
+}
+
+func redisOK(command string) string {
+	switch command {
+	case "PUBLISH":
+		return ":1\r\n"
+	case "XADD":
+		return "$15\r\n1700000000000-0\r\n"
+	default:
+		return "+OK\r\n"
+	}
+}
+
+func TestRedisPublish(t *testing.T) {
+	server := newFakeRedis(t, redisOK)
+
+	n := New().Redis("redis://:s3cret@"+server.listener.Addr().String()+"/2", "alerts")
+	if err := n.Send(map[string]interface{}{"text": "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "*2\r\n$4\r\nAUTH\r\n$6\r\ns3cret\r\n" +
+		"*2\r\n$6\r\nSELECT\r\n$1\r\n2\r\n" +
+		"*3\r\n$7\r\nPUBLISH\r\n$6\r\nalerts\r\n$13\r\n" + `{"text":"hi"}` + "\r\n"
+	if got := server.String(); got != want {
+		t.Fatalf("transcript =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRedisStream(t *testing.T) {
+	server := newFakeRedis(t, redisOK)
+
+	n := New().Redis("redis://ops:s3cret@"+server.listener.Addr().String(), "alerts", RedisStream(1000))
+	if err := n.Send(map[string]interface{}{"key": "db-1", "severity": "ERROR", "text": "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := `{"key":"db-1","severity":"ERROR","text":"hi"}`
+	want := "*3\r\n$4\r\nAUTH\r\n$3\r\nops\r\n$6\r\ns3cret\r\n" +
+		"*12\r\n$4\r\nXADD\r\n$6\r\nalerts\r\n$6\r\nMAXLEN\r\n$1\r\n~\r\n$4\r\n1000\r\n$1\r\n*\r\n" +
+		"$7\r\npayload\r\n$" + strconv.Itoa(len(payload)) + "\r\n" + payload + "\r\n" +
+		"$8\r\nseverity\r\n$5\r\nERROR\r\n" +
+		"$3\r\nkey\r\n$4\r\ndb-1\r\n"
+	if got := server.String(); got != want {
+		t.Fatalf("transcript =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRedisAuthError(t *testing.T) {
+	server := newFakeRedis(t, func(string) string {
+		return "-WRONGPASS invalid username-password pair or user is disabled.\r\n"
+	})
+
+	err := New().Redis("redis://:wrong@"+server.listener.Addr().String(), "alerts").Send("hi")
+	if err == nil || !strings.Contains(err.Error(), "redis AUTH: WRONGPASS") {
+		t.Fatalf("err = %v", err)
+	}
+}
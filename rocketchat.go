@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type RocketChatOption func(*rocketchat)
+
+// RocketChatColor 以帶顏色的 attachment 呈現純文字訊息，例如 "#d00000" 表示嚴重；
+// 結構化訊息的顏色則依 Severity / Status 決定
+func RocketChatColor(color string) RocketChatOption {
+	return func(r *rocketchat) { r.Color = color }
+}
+
+func RocketChatAlias(alias string) RocketChatOption {
+	return func(r *rocketchat) { r.Alias = alias }
+}
+
+// RocketChatWebhook 使用 incoming webhook 發送
+func (n *Notify) RocketChatWebhook(webhookURL string, opts ...RocketChatOption) *Notify {
+	r := &rocketchat{URL: webhookURL}
+	for _, opt := range opts {
+		opt(r)
+	}
+	n.add(r)
+	return n
+}
+
+// RocketChat 使用 REST API chat.postMessage 發送
+func (n *Notify) RocketChat(serverURL, userID, authToken, channel string, opts ...RocketChatOption) *Notify {
+	r := &rocketchat{
+		URL:       strings.TrimRight(serverURL, "/") + "/api/v1/chat.postMessage",
+		UserID:    userID,
+		AuthToken: authToken,
+		Channel:   channel,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	n.add(r)
+	return n
+}
+
+type rocketchat struct {
+	URL       string
+	UserID    string
+	AuthToken string
+	Channel   string
+	Color     string
+	Alias     string
+}
+
+func (r *rocketchat) Send(client *http.Client, message string) error {
+	payload := map[string]interface{}{}
+	if r.Color != "" {
+		payload["attachments"] = []map[string]interface{}{{
+			"color": r.Color,
+			"text":  message,
+		}}
+	} else {
+		payload["text"] = message
+	}
+	if r.Alias != "" {
+		payload["alias"] = r.Alias
+	}
+
+	return r.SendRaw(client, payload)
+}
+
+// SendMessage 以 attachment 呈現，顏色依 Severity / Status，Fields 以 short 欄位並排
+func (r *rocketchat) SendMessage(client *http.Client, message Message) error {
+	title := message.heading()
+
+	attachment := map[string]interface{}{
+		"color": severityColor(message),
+		"text":  message.Text,
+	}
+	if title != "" {
+		attachment["title"] = title
+	}
+	if message.URL != "" {
+		attachment["title_link"] = message.URL
+	}
+	var fields []map[string]interface{}
+	for _, field := range message.Fields {
+		fields = append(fields, map[string]interface{}{
+			"short": true,
+			"title": field.Name,
+			"value": field.Value,
+		})
+	}
+	if len(fields) > 0 {
+		attachment["fields"] = fields
+	}
+
+	payload := map[string]interface{}{
+		"attachments": []map[string]interface{}{attachment},
+	}
+	if r.Alias != "" {
+		payload["alias"] = r.Alias
+	}
+
+	return r.SendRaw(client, payload)
+}
+
+func (r *rocketchat) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["channel"]; !ok && r.Channel != "" {
+		message["channel"] = r.Channel
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", r.URL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if r.AuthToken != "" {
+		req.Header.Set("X-Auth-Token", r.AuthToken)
+		req.Header.Set("X-User-Id", r.UserID)
+	}
+
+	return request(client, req)
+}
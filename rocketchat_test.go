@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRocketChatSendMessage(t *testing.T) {
+	var got struct {
+		Channel     string `json:"channel"`
+		Attachments []struct {
+			Title     string `json:"title"`
+			TitleLink string `json:"title_link"`
+			Color     string `json:"color"`
+			Text      string `json:"text"`
+			Fields    []struct {
+				Title string `json:"title"`
+				Value string `json:"value"`
+			} `json:"fields"`
+		} `json:"attachments"`
+	}
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/chat.postMessage" || r.Header.Get("X-Auth-Token") != "token" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer done()
+
+	n.RocketChat("https://chat.example.com", "user", "token", "#ops")
+	err := n.Send(Message{
+		Title:    "DB down",
+		Text:     "primary unreachable",
+		Severity: SeverityCritical,
+		Fields:   []Field{{Name: "host", Value: "db-1"}},
+		URL:      "https://grafana.example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Channel != "#ops" || len(got.Attachments) != 1 {
+		t.Fatalf("payload = %+v", got)
+	}
+	a := got.Attachments[0]
+	if a.Title != "[CRITICAL] DB down" || a.Color != "#d00000" || a.TitleLink != "https://grafana.example.com" ||
+		a.Text != "primary unreachable" || len(a.Fields) != 1 || a.Fields[0].Value != "db-1" {
+		t.Fatalf("attachment = %+v", a)
+	}
+}
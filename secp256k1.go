@@ -0,0 +1,158 @@
+package notify
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// secp256k1 曲線運算，僅供 Nostr 的 BIP-340 簽章與 NIP-04 ECDH 使用，未做常數時間處理
+var (
+	secpP, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	secpN, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	secpGx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	secpGy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+)
+
+type secpPoint struct {
+	X, Y *big.Int
+}
+
+func (p *secpPoint) infinity() bool {
+	return p == nil
+}
+
+func secpAdd(a, b *secpPoint) *secpPoint {
+	if a.infinity() {
+		return b
+	}
+	if b.infinity() {
+		return a
+	}
+
+	var lambda *big.Int
+	if a.X.Cmp(b.X) == 0 {
+		if new(big.Int).Add(a.Y, b.Y).Mod(new(big.Int).Add(a.Y, b.Y), secpP).Sign() == 0 {
+			return nil
+		}
+		// lambda = 3x^2 / 2y
+		num := new(big.Int).Mul(a.X, a.X)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(a.Y, 1)
+		den.ModInverse(den, secpP)
+		lambda = num.Mul(num, den)
+	} else {
+		// lambda = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(b.Y, a.Y)
+		den := new(big.Int).Sub(b.X, a.X)
+		den.Mod(den, secpP)
+		den.ModInverse(den, secpP)
+		lambda = num.Mul(num, den)
+	}
+	lambda.Mod(lambda, secpP)
+
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.X)
+	x.Sub(x, b.X)
+	x.Mod(x, secpP)
+
+	y := new(big.Int).Sub(a.X, x)
+	y.Mul(y, lambda)
+	y.Sub(y, a.Y)
+	y.Mod(y, secpP)
+
+	return &secpPoint{X: x, Y: y}
+}
+
+func secpMul(p *secpPoint, k *big.Int) *secpPoint {
+	var result *secpPoint
+	addend := p
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = secpAdd(result, addend)
+		}
+		addend = secpAdd(addend, addend)
+	}
+	return result
+}
+
+func secpBaseMul(k *big.Int) *secpPoint {
+	return secpMul(&secpPoint{X: secpGx, Y: secpGy}, k)
+}
+
+// secpLiftX 由 x 座標取回 y 為偶數的點
+func secpLiftX(x *big.Int) (*secpPoint, error) {
+	if x.Cmp(secpP) >= 0 {
+		return nil, errors.New("invalid secp256k1 x coordinate")
+	}
+
+	c := new(big.Int).Exp(x, big.NewInt(3), secpP)
+	c.Add(c, big.NewInt(7))
+	c.Mod(c, secpP)
+
+	exp := new(big.Int).Add(secpP, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	y := new(big.Int).Exp(c, exp, secpP)
+	if new(big.Int).Exp(y, big.NewInt(2), secpP).Cmp(c) != 0 {
+		return nil, errors.New("invalid secp256k1 public key")
+	}
+	if y.Bit(0) == 1 {
+		y.Sub(secpP, y)
+	}
+
+	return &secpPoint{X: x, Y: y}, nil
+}
+
+func taggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func bytes32(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
+
+// schnorrSign BIP-340 簽章
+func schnorrSign(secret *big.Int, message, aux []byte) ([]byte, error) {
+	if secret.Sign() == 0 || secret.Cmp(secpN) >= 0 {
+		return nil, errors.New("invalid secp256k1 private key")
+	}
+
+	P := secpBaseMul(secret)
+	d := new(big.Int).Set(secret)
+	if P.Y.Bit(0) == 1 {
+		d.Sub(secpN, d)
+	}
+
+	t := bytes32(d)
+	auxHash := taggedHash("BIP0340/aux", aux)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+
+	k := new(big.Int).SetBytes(taggedHash("BIP0340/nonce", t, bytes32(P.X), message))
+	k.Mod(k, secpN)
+	if k.Sign() == 0 {
+		return nil, errors.New("invalid schnorr nonce")
+	}
+
+	R := secpBaseMul(k)
+	if R.Y.Bit(0) == 1 {
+		k.Sub(secpN, k)
+	}
+
+	e := new(big.Int).SetBytes(taggedHash("BIP0340/challenge", bytes32(R.X), bytes32(P.X), message))
+	e.Mod(e, secpN)
+
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, secpN)
+
+	return append(bytes32(R.X), bytes32(s)...), nil
+}
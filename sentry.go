@@ -0,0 +1,190 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+type SentryOption func(*sentry)
+
+func SentryTags(tags map[string]string) SentryOption {
+	return func(s *sentry) {
+		for key, value := range tags {
+			s.Tags[key] = value
+		}
+	}
+}
+
+func SentryEnvironment(environment string) SentryOption {
+	return func(s *sentry) { s.Environment = environment }
+}
+
+func SentryRelease(release string) SentryOption {
+	return func(s *sentry) { s.Release = release }
+}
+
+// Sentry dsn 例如 https://<key>@o0.ingest.sentry.io/<project>
+func (n *Notify) Sentry(dsn string, opts ...SentryOption) *Notify {
+	s := &sentry{
+		DSN:  dsn,
+		Tags: map[string]string{},
+	}
+	s.ServerName, _ = os.Hostname()
+
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.Path == "" {
+		s.err = errors.New("invalid sentry dsn")
+	} else {
+		s.PublicKey = u.User.Username()
+		project := strings.Trim(u.Path, "/")
+		// DSN 路徑可能帶有前綴，最後一段才是 project ID
+		prefix := ""
+		if i := strings.LastIndex(project, "/"); i >= 0 {
+			prefix, project = "/"+project[:i], project[i+1:]
+		}
+		s.Endpoint = fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, project)
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	n.add(s)
+	return n
+}
+
+type sentry struct {
+	DSN         string
+	PublicKey   string
+	Endpoint    string
+	Tags        map[string]string
+	Environment string
+	Release     string
+	ServerName  string
+
+	err error
+}
+
+func (s *sentry) Send(client *http.Client, message string) error {
+	return s.SendRaw(client, map[string]interface{}{
+		"level":   "error",
+		"message": map[string]string{"formatted": message},
+	})
+}
+
+// SendMessage Key 作為 fingerprint，同一事件歸到同一個 issue；Resolved 無法透過事件關閉 issue，直接略過
+func (s *sentry) SendMessage(client *http.Client, message Message) error {
+	if message.Status == StatusResolved {
+		return nil
+	}
+
+	level := "info"
+	switch message.Severity {
+	case SeverityWarning:
+		level = "warning"
+	case SeverityError:
+		level = "error"
+	case SeverityCritical:
+		level = "fatal"
+	}
+
+	text := message.Title
+	if message.Text != "" {
+		text = strings.TrimSpace(text + "\n" + message.Text)
+	}
+
+	event := map[string]interface{}{
+		"level":   level,
+		"message": map[string]string{"formatted": text},
+	}
+
+	extra := map[string]string{}
+	for _, field := range message.Fields {
+		extra[field.Name] = field.Value
+	}
+	if message.URL != "" {
+		extra["url"] = message.URL
+	}
+	if len(extra) > 0 {
+		event["extra"] = extra
+	}
+	if message.Key != "" {
+		event["fingerprint"] = []string{message.Key}
+	}
+
+	return s.SendRaw(client, event)
+}
+
+// SendRaw 傳入 Sentry event 物件，缺少的 event_id、timestamp、tags 等欄位會自動補上
+func (s *sentry) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if _, ok := message["event_id"]; !ok {
+		id := make([]byte, 16)
+		rand.Read(id)
+		message["event_id"] = hex.EncodeToString(id)
+	}
+	if _, ok := message["timestamp"]; !ok {
+		message["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	if _, ok := message["platform"]; !ok {
+		message["platform"] = "go"
+	}
+	if _, ok := message["logger"]; !ok {
+		message["logger"] = "notify"
+	}
+	if _, ok := message["tags"]; !ok && len(s.Tags) > 0 {
+		message["tags"] = s.Tags
+	}
+	if _, ok := message["environment"]; !ok && s.Environment != "" {
+		message["environment"] = s.Environment
+	}
+	if _, ok := message["release"]; !ok && s.Release != "" {
+		message["release"] = s.Release
+	}
+	if _, ok := message["server_name"]; !ok && s.ServerName != "" {
+		message["server_name"] = s.ServerName
+	}
+
+	event, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	header, _ := json.Marshal(map[string]interface{}{
+		"event_id": message["event_id"],
+		"dsn":      s.DSN,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+	})
+	item, _ := json.Marshal(map[string]interface{}{
+		"type":   "event",
+		"length": len(event),
+	})
+
+	var envelope bytes.Buffer
+	envelope.Write(header)
+	envelope.WriteByte('\n')
+	envelope.Write(item)
+	envelope.WriteByte('\n')
+	envelope.Write(event)
+	envelope.WriteByte('\n')
+
+	req, err := http.NewRequest("POST", s.Endpoint, &envelope)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=notify-go/1.0, sentry_key="+s.PublicKey)
+
+	return request(client, req)
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var serverChan3Key = regexp.MustCompile(`^sctp(\d+)t`)
+
+func (n *Notify) ServerChan(sendKey string) *Notify {
+	n.add(&serverchan{
+		SendKey: sendKey,
+	})
+	return n
+}
+
+type serverchan struct {
+	SendKey string
+}
+
+func (s *serverchan) Send(client *http.Client, message string) error {
+	// title 上限 32 字，完整內容放在 desp
+	title := subjectOf(message)
+	if r := []rune(title); len(r) > 32 {
+		title = string(r[:32])
+	}
+
+	return s.SendRaw(client, map[string]interface{}{
+		"title": title,
+		"desp":  message,
+	})
+}
+
+func (s *serverchan) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+
+	url := fmt.Sprintf("https://sctapi.ftqq.com/%s.send", s.SendKey)
+	if m := serverChan3Key.FindStringSubmatch(s.SendKey); m != nil {
+		url = fmt.Sprintf("https://%s.push.ft07.com/send/%s.send", m[1], s.SendKey)
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("serverchan responded with code %d: %s", result.Code, result.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AzureServiceBus 使用 SAS 連線字串，entity 為 queue 或 topic 名稱；
+// 連線字串含 EntityPath 時 entity 可留空
+func (n *Notify) AzureServiceBus(connectionString, entity string) *Notify {
+	s := &serviceBus{Entity: entity}
+	for _, part := range strings.Split(connectionString, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "endpoint":
+			if u, err := url.Parse(value); err == nil {
+				s.Namespace = u.Host
+			}
+		case "sharedaccesskeyname":
+			s.KeyName = value
+		case "sharedaccesskey":
+			s.Key = value
+		case "entitypath":
+			if s.Entity == "" {
+				s.Entity = value
+			}
+		}
+	}
+	if s.Namespace == "" || s.KeyName == "" || s.Key == "" || s.Entity == "" {
+		s.err = errors.New("invalid azure service bus connection string")
+	}
+	n.add(s)
+	return n
+}
+
+// AzureServiceBusAAD 使用 Azure AD (Entra ID) 應用程式的 client credentials，
+// namespace 例如 mynamespace.servicebus.windows.net，應用程式需有 Azure Service Bus Data Sender 角色
+func (n *Notify) AzureServiceBusAAD(namespace, entity, tenantID, clientID, clientSecret string) *Notify {
+	n.add(&serviceBus{
+		Namespace:    namespace,
+		Entity:       entity,
+		TenantID:     tenantID,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+	return n
+}
+
+type serviceBus struct {
+	Namespace    string
+	Entity       string
+	KeyName      string
+	Key          string
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+	err         error
+}
+
+func (s *serviceBus) Send(client *http.Client, message string) error {
+	return s.post(client, []byte(message), "text/plain", nil)
+}
+
+// SendMessage 以 JSON 送出，Key 與 Status 作為 MessageId (啟用 duplicate detection 時可去重)，Title 作為 Label
+func (s *serviceBus) SendMessage(client *http.Client, message Message) error {
+	record := messageRecord(message)
+	record.Timestamp = time.Now().UTC()
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	properties := map[string]interface{}{}
+	if message.Key != "" {
+		// 只用 Key 時 resolved 會被當成 firing 的重複訊息丟棄
+		properties["MessageId"] = deduplicationID(message.Key, message.Status)
+	}
+	if message.Title != "" {
+		properties["Label"] = message.Title
+	}
+	return s.post(client, jsonData, "application/json", properties, "severity", record.Severity, "status", record.Status)
+}
+
+// SendRaw 支援 {"body": ..., "properties": BrokerProperties}，body 非字串時以 JSON 送出
+func (s *serviceBus) SendRaw(client *http.Client, message map[string]interface{}) error {
+	properties, _ := message["properties"].(map[string]interface{})
+
+	if body, ok := message["body"].(string); ok {
+		return s.post(client, []byte(body), "text/plain", properties)
+	}
+
+	body, ok := message["body"]
+	if !ok {
+		body = message
+	}
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	return s.post(client, jsonData, "application/json", properties)
+}
+
+// post customProperties 為成對的名稱與值，以自訂 header 送出
+func (s *serviceBus) post(client *http.Client, body []byte, contentType string, properties map[string]interface{}, customProperties ...string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	auth, err := s.authorization(client)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://%s/%s/messages", s.Namespace, s.Entity)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", auth)
+	if len(properties) > 0 {
+		jsonData, err := json.Marshal(properties)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		req.Header.Set("BrokerProperties", string(jsonData))
+	}
+	for i := 0; i+1 < len(customProperties); i += 2 {
+		// 字串型別的自訂屬性需加上引號
+		req.Header.Set(customProperties[i], strconv.Quote(customProperties[i+1]))
+	}
+
+	return request(client, req)
+}
+
+func (s *serviceBus) authorization(client *http.Client) (string, error) {
+	if s.Key != "" {
+		resource := url.QueryEscape(fmt.Sprintf("https://%s/%s", s.Namespace, s.Entity))
+		expiry := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(s.Key))
+		mac.Write([]byte(resource + "\n" + expiry))
+		signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		return fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s&skn=%s",
+			resource, url.QueryEscape(signature), expiry, url.QueryEscape(s.KeyName)), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Before(s.expiresAt) {
+		return "Bearer " + s.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", s.ClientID)
+	form.Set("client_secret", s.ClientSecret)
+	form.Set("scope", "https://servicebus.azure.net/.default")
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", s.TenantID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+
+	s.accessToken = result.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - 5*time.Minute)
+	return "Bearer " + s.accessToken, nil
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestServiceBusMessageID(t *testing.T) {
+	var ids []string
+	var auth string
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var properties map[string]interface{}
+		json.Unmarshal([]byte(r.Header.Get("BrokerProperties")), &properties)
+		id, _ := properties["MessageId"].(string)
+		ids = append(ids, id)
+		auth = r.Header.Get("Authorization")
+	}))
+	defer done()
+
+	n.AzureServiceBus("Endpoint=sb://ops.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=c2VjcmV0", "alerts")
+	for _, message := range []Message{
+		{Key: "db-1", Title: "down"},
+		{Key: "db-1", Title: "up", Status: StatusResolved},
+	} {
+		if err := n.Send(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !strings.HasPrefix(auth, "SharedAccessSignature sr=") || !strings.Contains(auth, "&skn=send") {
+		t.Fatalf("Authorization = %q", auth)
+	}
+	if want := []string{"db-1/firing", "db-1/resolved"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("MessageId = %q, want %q", ids, want)
+	}
+}
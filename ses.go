@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type SESOption func(*ses)
+
+func SESSubject(subject string) SESOption {
+	return func(s *ses) { s.Subject = subject }
+}
+
+// SESCredentials 指定固定金鑰，未設定時使用 AWS 預設憑證鏈
+func SESCredentials(accessKeyID, secretAccessKey, sessionToken string) SESOption {
+	return func(s *ses) {
+		s.Signer.Static = &awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		}
+	}
+}
+
+func SESConfigurationSet(name string) SESOption {
+	return func(s *ses) { s.ConfigurationSet = name }
+}
+
+func (n *Notify) SES(region, from string, to []string, opts ...SESOption) *Notify {
+	s := &ses{
+		From:   from,
+		To:     to,
+		Signer: newAWSSigner(region, "ses"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	n.add(s)
+	return n
+}
+
+type ses struct {
+	From             string
+	To               []string
+	Subject          string
+	ConfigurationSet string
+	Signer           *awsSigner
+}
+
+func (s *ses) Send(client *http.Client, message string) error {
+	subject := s.Subject
+	if subject == "" {
+		subject = subjectOf(message)
+	}
+
+	return s.SendRaw(client, map[string]interface{}{
+		"Content": map[string]interface{}{
+			"Simple": map[string]interface{}{
+				"Subject": map[string]interface{}{"Data": subject, "Charset": "UTF-8"},
+				"Body": map[string]interface{}{
+					"Text": map[string]interface{}{"Data": message, "Charset": "UTF-8"},
+				},
+			},
+		},
+	})
+}
+
+func (s *ses) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["FromEmailAddress"]; !ok {
+		message["FromEmailAddress"] = s.From
+	}
+	if _, ok := message["Destination"]; !ok {
+		message["Destination"] = map[string]interface{}{"ToAddresses": s.To}
+	}
+	if _, ok := message["ConfigurationSetName"]; !ok && s.ConfigurationSet != "" {
+		message["ConfigurationSetName"] = s.ConfigurationSet
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/outbound-emails", s.Signer.Region)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if err := s.Signer.sign(client, req, jsonData); err != nil {
+		return fmt.Errorf("failed to sign request: %v", err)
+	}
+
+	return request(client, req)
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Signal 透過 signal-cli-rest-api 閘道發送，number 為已註冊的發送號碼
+func (n *Notify) Signal(apiURL, number string, recipients []string) *Notify {
+	n.add(&signal{
+		APIURL:     strings.TrimRight(apiURL, "/"),
+		Number:     number,
+		Recipients: recipients,
+	})
+	return n
+}
+
+type signal struct {
+	APIURL     string
+	Number     string
+	Recipients []string
+}
+
+func (s *signal) Send(client *http.Client, message string) error {
+	return s.SendRaw(client, map[string]interface{}{"message": message})
+}
+
+// SendRaw attachments 欄位為 [][]byte 時會轉成 base64_attachments
+func (s *signal) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["number"]; !ok {
+		message["number"] = s.Number
+	}
+	if _, ok := message["recipients"]; !ok {
+		message["recipients"] = s.Recipients
+	}
+	if attachments, ok := message["attachments"].([][]byte); ok {
+		var encoded []string
+		for _, attachment := range attachments {
+			encoded = append(encoded, base64.StdEncoding.EncodeToString(attachment))
+		}
+		delete(message, "attachments")
+		message["base64_attachments"] = encoded
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", s.APIURL+"/v2/send", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type SNSOption func(*sns)
+
+func SNSSubject(subject string) SNSOption {
+	return func(s *sns) { s.Subject = subject }
+}
+
+// SNSAttribute 加入字串型別的 message attribute，可供訂閱端 filter policy 使用
+func SNSAttribute(name, value string) SNSOption {
+	return func(s *sns) { s.Attributes[name] = value }
+}
+
+func SNSCredentials(accessKeyID, secretAccessKey, sessionToken string) SNSOption {
+	return func(s *sns) {
+		s.Signer.Static = &awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		}
+	}
+}
+
+func (n *Notify) SNS(topicARN string, opts ...SNSOption) *Notify {
+	// arn:aws:sns:<region>:<account>:<topic>
+	region := ""
+	if parts := strings.Split(topicARN, ":"); len(parts) > 3 {
+		region = parts[3]
+	}
+
+	s := &sns{
+		TopicARN:   topicARN,
+		Attributes: map[string]string{},
+		Signer:     newAWSSigner(region, "sns"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	n.add(s)
+	return n
+}
+
+type sns struct {
+	TopicARN   string
+	Subject    string
+	Attributes map[string]string
+	Signer     *awsSigner
+}
+
+func (s *sns) Send(client *http.Client, message string) error {
+	form := url.Values{}
+	form.Set("Message", message)
+	if s.Subject != "" {
+		form.Set("Subject", s.Subject)
+	}
+
+	names := make([]string, 0, len(s.Attributes))
+	for name := range s.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		prefix := fmt.Sprintf("MessageAttributes.entry.%d.", i+1)
+		form.Set(prefix+"Name", name)
+		form.Set(prefix+"Value.DataType", "String")
+		form.Set(prefix+"Value.StringValue", s.Attributes[name])
+	}
+
+	return s.post(client, form)
+}
+
+func (s *sns) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+
+	return s.post(client, form)
+}
+
+func (s *sns) post(client *http.Client, form url.Values) error {
+	if !form.Has("Action") {
+		form.Set("Action", "Publish")
+	}
+	if !form.Has("TopicArn") && !form.Has("TargetArn") && !form.Has("PhoneNumber") {
+		form.Set("TopicArn", s.TopicARN)
+	}
+	form.Set("Version", "2010-03-31")
+
+	body := []byte(form.Encode())
+	url := fmt.Sprintf("https://sns.%s.amazonaws.com/", s.Signer.Region)
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if err := s.Signer.sign(client, req, body); err != nil {
+		return fmt.Errorf("failed to sign request: %v", err)
+	}
+
+	return request(client, req)
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SplunkOnCall (VictorOps) REST endpoint 整合
+func (n *Notify) SplunkOnCall(apiKey, routingKey string) *Notify {
+	n.add(&splunkoncall{
+		APIKey:     apiKey,
+		RoutingKey: routingKey,
+	})
+	return n
+}
+
+type splunkoncall struct {
+	APIKey     string
+	RoutingKey string
+}
+
+func (s *splunkoncall) Send(client *http.Client, message string) error {
+	return s.SendMessage(client, Message{
+		Title:    subjectOf(message),
+		Text:     message,
+		Severity: SeverityCritical,
+	})
+}
+
+// SendMessage 以 Key 作為 entity_id，之後送出同 Key 的 StatusResolved 即自動恢復
+func (s *splunkoncall) SendMessage(client *http.Client, message Message) error {
+	alert := map[string]interface{}{
+		"message_type":        splunkMessageType(message),
+		"entity_display_name": message.Title,
+		"state_message":       message.String(),
+		"monitoring_tool":     "notify-go",
+	}
+	if message.Key != "" {
+		alert["entity_id"] = message.Key
+	}
+	for _, field := range message.Fields {
+		alert[field.Name] = field.Value
+	}
+	if message.URL != "" {
+		alert["alert_url"] = message.URL
+	}
+
+	return s.SendRaw(client, alert)
+}
+
+func splunkMessageType(message Message) string {
+	switch message.Status {
+	case StatusAcknowledged:
+		return "ACKNOWLEDGEMENT"
+	case StatusResolved:
+		return "RECOVERY"
+	}
+
+	switch message.Severity {
+	case SeverityCritical, SeverityError:
+		return "CRITICAL"
+	case SeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+func (s *splunkoncall) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://alert.victorops.com/integrations/generic/20131114/alert/%s/%s", s.APIKey, s.RoutingKey)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
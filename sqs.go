@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type SQSOption func(*sqs)
+
+// SQSAttribute 加入字串型別的 message attribute
+func SQSAttribute(name, value string) SQSOption {
+	return func(s *sqs) { s.Attributes[name] = value }
+}
+
+// SQSMessageGroup FIFO queue 的 MessageGroupId，預設 "notify"
+func SQSMessageGroup(groupID string) SQSOption {
+	return func(s *sqs) { s.GroupID = groupID }
+}
+
+func SQSCredentials(accessKeyID, secretAccessKey, sessionToken string) SQSOption {
+	return func(s *sqs) {
+		s.Signer.Static = &awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		}
+	}
+}
+
+// SQS queueURL 例如 https://sqs.us-east-1.amazonaws.com/123456789012/alerts.fifo，
+// FIFO queue 以 Message.Key 與 Status 作為 MessageDeduplicationId
+func (n *Notify) SQS(queueURL string, opts ...SQSOption) *Notify {
+	// sqs.<region>.amazonaws.com
+	region := ""
+	if u, err := url.Parse(queueURL); err == nil {
+		if parts := strings.Split(u.Hostname(), "."); len(parts) > 2 && parts[0] == "sqs" {
+			region = parts[1]
+		}
+	}
+
+	s := &sqs{
+		QueueURL:   queueURL,
+		GroupID:    "notify",
+		Attributes: map[string]string{},
+		Signer:     newAWSSigner(region, "sqs"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	n.add(s)
+	return n
+}
+
+type sqs struct {
+	QueueURL   string
+	GroupID    string
+	Attributes map[string]string
+	Signer     *awsSigner
+}
+
+func (s *sqs) Send(client *http.Client, message string) error {
+	return s.send(client, message, "", nil)
+}
+
+func (s *sqs) SendMessage(client *http.Client, message Message) error {
+	record := messageRecord(message)
+	record.Timestamp = time.Now().UTC()
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	key := ""
+	if message.Key != "" {
+		key = deduplicationID(message.Key, message.Status)
+	}
+	return s.send(client, string(jsonData), key, map[string]string{
+		"severity": record.Severity,
+		"status":   record.Status,
+	})
+}
+
+// SendRaw 直接作為 SendMessage API 的參數，例如 {"MessageBody": ..., "DelaySeconds": 30}
+func (s *sqs) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["QueueUrl"]; !ok {
+		message["QueueUrl"] = s.QueueURL
+	}
+	if _, ok := message["MessageGroupId"]; !ok && s.fifo() {
+		message["MessageGroupId"] = s.GroupID
+	}
+	return s.post(client, message)
+}
+
+func (s *sqs) fifo() bool {
+	return strings.HasSuffix(s.QueueURL, ".fifo")
+}
+
+func (s *sqs) send(client *http.Client, body, key string, attributes map[string]string) error {
+	message := map[string]interface{}{
+		"QueueUrl":    s.QueueURL,
+		"MessageBody": body,
+	}
+
+	values := map[string]interface{}{}
+	for _, attrs := range []map[string]string{attributes, s.Attributes} {
+		for name, value := range attrs {
+			values[name] = map[string]string{"DataType": "String", "StringValue": value}
+		}
+	}
+	if len(values) > 0 {
+		message["MessageAttributes"] = values
+	}
+
+	if s.fifo() {
+		message["MessageGroupId"] = s.GroupID
+		if key == "" {
+			// 未指定 key 時以內容去重，與 content-based deduplication 相同
+			key = sha256Hex([]byte(body))
+		}
+		message["MessageDeduplicationId"] = key
+	}
+
+	return s.post(client, message)
+}
+
+// deduplicationID 由 Key 與 Status 組成，避免同一事件的 resolved 被當成 firing 的重複訊息丟棄；
+// SQS 與 Service Bus 皆限制 128 字元，超過或含非可見 ASCII 字元時改用 SHA-256
+func deduplicationID(key string, status Status) string {
+	id := key + "/" + strings.ToLower(status.String())
+	if len(id) > 128 {
+		return sha256Hex([]byte(id))
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return sha256Hex([]byte(id))
+		}
+	}
+	return id
+}
+
+func (s *sqs) post(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://sqs.%s.amazonaws.com/", s.Signer.Region)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS.SendMessage")
+	if err := s.Signer.sign(client, req, jsonData); err != nil {
+		return fmt.Errorf("failed to sign request: %v", err)
+	}
+
+	return request(client, req)
+}
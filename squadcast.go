@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Squadcast 使用 Incident Webhook 整合的網址
+func (n *Notify) Squadcast(webhookURL string) *Notify {
+	n.add(&squadcast{
+		WebhookURL: webhookURL,
+	})
+	return n
+}
+
+type squadcast struct {
+	WebhookURL string
+}
+
+func (s *squadcast) Send(client *http.Client, message string) error {
+	return s.SendMessage(client, Message{
+		Title: subjectOf(message),
+		Text:  message,
+	})
+}
+
+// SendMessage 以 Key 作為 event_id 去重，StatusResolved 會解除同 event_id 的事件
+func (s *squadcast) SendMessage(client *http.Client, message Message) error {
+	status := "trigger"
+	if message.Status == StatusResolved {
+		status = "resolve"
+	}
+
+	tags := map[string]string{
+		"severity": message.Severity.String(),
+	}
+	for _, field := range message.Fields {
+		tags[field.Name] = field.Value
+	}
+	if message.URL != "" {
+		tags["url"] = message.URL
+	}
+
+	event := map[string]interface{}{
+		"message":     message.Title,
+		"description": message.Text,
+		"status":      status,
+		"tags":        tags,
+	}
+	if message.Key != "" {
+		event["event_id"] = message.Key
+	}
+
+	return s.SendRaw(client, event)
+}
+
+func (s *squadcast) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", s.WebhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
@@ -0,0 +1,218 @@
+package notify
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SyslogUser   = 1
+	SyslogDaemon = 3
+	SyslogLocal0 = 16
+	SyslogLocal7 = 23
+)
+
+type SyslogOption func(*syslog)
+
+// SyslogFacility 預設 SyslogUser
+func SyslogFacility(facility int) SyslogOption {
+	return func(s *syslog) { s.Facility = facility }
+}
+
+func SyslogAppName(name string) SyslogOption {
+	return func(s *syslog) { s.AppName = name }
+}
+
+// SyslogTLSConfig network 為 "tls" 時使用的設定
+func SyslogTLSConfig(config *tls.Config) SyslogOption {
+	return func(s *syslog) { s.TLSConfig = config }
+}
+
+// Syslog network 為 udp、tcp、tls，或留空寫入本機 syslog (/dev/log)；
+// 遠端以 RFC 5424 格式送出，tcp/tls 使用 octet-counting framing
+func (n *Notify) Syslog(network, address string, opts ...SyslogOption) *Notify {
+	hostname, _ := os.Hostname()
+	s := &syslog{
+		Network:  network,
+		Address:  address,
+		Facility: SyslogUser,
+		AppName:  filepath.Base(os.Args[0]),
+		Hostname: hostname,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	n.add(s)
+	return n
+}
+
+type syslog struct {
+	Network   string
+	Address   string
+	Facility  int
+	AppName   string
+	Hostname  string
+	TLSConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (s *syslog) Send(_ *http.Client, message string) error {
+	return s.write(6, "", message)
+}
+
+// SendMessage 嚴重度對應 syslog level，Fields 轉為 structured data
+func (s *syslog) SendMessage(_ *http.Client, message Message) error {
+	var sd string
+	if len(message.Fields) > 0 || message.Key != "" {
+		var params []string
+		for _, field := range message.Fields {
+			params = append(params, fmt.Sprintf(`%s="%s"`, syslogParamName(field.Name), syslogParamValue(field.Value)))
+		}
+		if message.Key != "" {
+			params = append(params, fmt.Sprintf(`key="%s"`, syslogParamValue(message.Key)))
+		}
+		sd = "[notify@32473 " + strings.Join(params, " ") + "]"
+	}
+
+	text := message.Title
+	if message.Text != "" {
+		text = strings.TrimSpace(text + ": " + message.Text)
+	}
+
+	return s.write(syslogLevel(message), sd, text)
+}
+
+// SendRaw 支援 {"severity": 0~7, "message": 內容}
+func (s *syslog) SendRaw(_ *http.Client, message map[string]interface{}) error {
+	text, _ := message["message"].(string)
+	level, ok := message["severity"].(int)
+	if !ok {
+		level = 6
+	}
+	return s.write(level, "", text)
+}
+
+func syslogLevel(message Message) int {
+	if message.Status == StatusResolved {
+		return 5
+	}
+	switch message.Severity {
+	case SeverityWarning:
+		return 4
+	case SeverityError:
+		return 3
+	case SeverityCritical:
+		return 2
+	default:
+		return 6
+	}
+}
+
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= 32 || r >= 127 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	return name
+}
+
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+func (s *syslog) write(level int, sd, text string) error {
+	pri := s.Facility*8 + level
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var line string
+	if s.Network == "" {
+		line = fmt.Sprintf("<%d>%s %s[%d]: %s", pri, time.Now().Format(time.Stamp), s.AppName, os.Getpid(), text)
+	} else {
+		if sd == "" {
+			sd = "-"
+		}
+		line = fmt.Sprintf("<%d>1 %s %s %s %d - %s \ufeff%s",
+			pri, time.Now().Format(time.RFC3339Nano), syslogHeader(s.Hostname), syslogHeader(s.AppName), os.Getpid(), sd, text)
+	}
+	if s.Network == "tcp" || s.Network == "tls" {
+		line = fmt.Sprintf("%d %s", len(line), line)
+	}
+
+	err := s.send(line)
+	if err != nil {
+		// 連線可能已中斷，重連一次
+		s.close()
+		err = s.send(line)
+	}
+	return err
+}
+
+func syslogHeader(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, " ", "_")
+}
+
+func (s *syslog) send(line string) error {
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := s.conn.Write([]byte(line)); err != nil {
+		return fmt.Errorf("failed to write syslog: %v", err)
+	}
+	return nil
+}
+
+func (s *syslog) dial() (net.Conn, error) {
+	switch s.Network {
+	case "":
+		for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+			for _, network := range []string{"unixgram", "unix"} {
+				if conn, err := net.Dial(network, path); err == nil {
+					return conn, nil
+				}
+			}
+		}
+		return nil, errors.New("local syslog not available")
+	case "tls":
+		config := s.TLSConfig
+		if config == nil {
+			host, _, _ := net.SplitHostPort(s.Address)
+			config = &tls.Config{ServerName: host}
+		}
+		return tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", s.Address, config)
+	default:
+		return net.DialTimeout(s.Network, s.Address, 10*time.Second)
+	}
+}
+
+func (s *syslog) close() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
@@ -0,0 +1,290 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TailOption func(*Tailer)
+
+// TailPattern 符合 pattern (regexp) 的行以 severity 通知，可指定多個；
+// 未指定時使用 "panic:"、"FATAL" (Critical) 與 "ERROR" (Error)
+func TailPattern(pattern string, severity Severity) TailOption {
+	return func(t *Tailer) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			t.err = fmt.Errorf("invalid tail pattern %q: %v", pattern, err)
+			return
+		}
+		t.patterns = append(t.patterns, tailPattern{regexp: re, severity: severity})
+	}
+}
+
+// TailContext 附上符合行之前與之後的行數，預設前 3 行、後 10 行 (足以涵蓋 panic 的 stack 開頭)
+func TailContext(before, after int) TailOption {
+	return func(t *Tailer) {
+		t.before = before
+		t.after = after
+	}
+}
+
+// TailThrottle 相同 pattern 在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func TailThrottle(window time.Duration, burst int) TailOption {
+	return func(t *Tailer) { t.throttle = NewThrottle(window, burst) }
+}
+
+// TailPoll 檢查檔案的間隔，預設 1 秒
+func TailPoll(interval time.Duration) TailOption {
+	return func(t *Tailer) { t.poll = interval }
+}
+
+type tailPattern struct {
+	regexp   *regexp.Regexp
+	severity Severity
+}
+
+// Tail 追蹤 log 檔新增的內容 (類似 tail -F)，符合 pattern 的行連同前後文送出；
+// 從目前檔尾開始，檔案被 rotate 或 truncate 時會重新開啟
+func (n *Notify) Tail(path string, opts ...TailOption) *Tailer {
+	t := &Tailer{
+		Path:     path,
+		notify:   n,
+		before:   3,
+		after:    10,
+		poll:     time.Second,
+		throttle: NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if len(t.patterns) == 0 && t.err == nil {
+		TailPattern(`panic:|\bFATAL\b`, SeverityCritical)(t)
+		TailPattern(`\bERROR\b`, SeverityError)(t)
+	}
+	return t
+}
+
+type Tailer struct {
+	Path string
+
+	notify   *Notify
+	patterns []tailPattern
+	before   int
+	after    int
+	poll     time.Duration
+	throttle *Throttle
+	err      error
+
+	file    *os.File
+	info    os.FileInfo
+	partial []byte
+	recent  []string
+	pending *tailMatch
+}
+
+type tailMatch struct {
+	pattern   tailPattern
+	first     string
+	lines     []string
+	remaining int
+}
+
+// Run 持續追蹤直到 ctx 結束；檔案尚不存在時會等待建立，pattern 無效時立即回傳錯誤
+func (t *Tailer) Run(ctx context.Context) error {
+	if t.err != nil {
+		return t.err
+	}
+	defer func() {
+		if t.file != nil {
+			t.file.Close()
+		}
+	}()
+
+	if err := t.open(true); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	ticker := time.NewTicker(t.poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			t.flush()
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := t.check(); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+}
+
+// open 開啟檔案，atEnd 時從檔尾開始 (啟動時不處理既有內容)
+func (t *Tailer) open(atEnd bool) error {
+	file, err := os.Open(t.Path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if atEnd {
+		if _, err := file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if t.file != nil {
+		t.file.Close()
+	}
+	t.file = file
+	t.info = info
+	t.partial = nil
+	t.recent = nil
+	return nil
+}
+
+func (t *Tailer) check() error {
+	if t.file == nil {
+		// 啟動時不存在的檔案，建立後從頭讀取
+		if err := t.open(false); err != nil {
+			return err
+		}
+	}
+
+	read, err := t.readNew()
+	if err != nil {
+		return err
+	}
+
+	// 讀完目前內容後才檢查 rotate，避免漏掉舊檔最後寫入的行
+	info, err := os.Stat(t.Path)
+	if err == nil {
+		offset, _ := t.file.Seek(0, io.SeekCurrent)
+		if !os.SameFile(info, t.info) || info.Size() < offset {
+			t.flush()
+			if err := t.open(false); err != nil {
+				return err
+			}
+			more, err := t.readNew()
+			if err != nil {
+				return err
+			}
+			read = read || more
+		}
+	}
+
+	// 沒有新內容時不再等待後文，直接送出
+	if !read {
+		t.flush()
+	}
+	return nil
+}
+
+func (t *Tailer) readNew() (bool, error) {
+	data, err := io.ReadAll(t.file)
+	if err != nil {
+		return false, err
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+
+	t.partial = append(t.partial, data...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		t.line(strings.TrimRight(string(t.partial[:i]), "\r"))
+		t.partial = t.partial[i+1:]
+	}
+	return true, nil
+}
+
+func (t *Tailer) line(line string) {
+	pattern, matched := t.match(line)
+
+	if t.pending != nil {
+		t.pending.lines = append(t.pending.lines, line)
+		if matched {
+			// 連續的錯誤合併為一則，取最高的嚴重度
+			if pattern.severity > t.pending.pattern.severity {
+				t.pending.pattern = pattern
+			}
+			t.pending.remaining = t.after
+		} else {
+			t.pending.remaining--
+		}
+		if t.pending.remaining <= 0 || len(t.pending.lines) >= 100 {
+			t.flush()
+		}
+	} else if matched {
+		lines := append(append([]string(nil), t.recent...), line)
+		t.pending = &tailMatch{pattern: pattern, first: line, lines: lines, remaining: t.after}
+		if t.after <= 0 {
+			t.flush()
+		}
+	}
+
+	if t.before > 0 {
+		t.recent = append(t.recent, line)
+		if len(t.recent) > t.before {
+			t.recent = t.recent[len(t.recent)-t.before:]
+		}
+	}
+}
+
+func (t *Tailer) match(line string) (tailPattern, bool) {
+	for _, pattern := range t.patterns {
+		if pattern.regexp.MatchString(line) {
+			return pattern, true
+		}
+	}
+	return tailPattern{}, false
+}
+
+func (t *Tailer) flush() {
+	pending := t.pending
+	if pending == nil {
+		return
+	}
+	t.pending = nil
+
+	key := "tail/" + t.Path + "/" + pending.pattern.regexp.String()
+	if !t.throttle.Allow(key) {
+		return
+	}
+
+	title := strings.TrimSpace(pending.first)
+	if r := []rune(title); len(r) > 120 {
+		title = string(r[:120]) + "…"
+	}
+	fields := []Field{
+		{Name: "file", Value: t.Path},
+		{Name: "pattern", Value: pending.pattern.regexp.String()},
+	}
+	if dropped := t.throttle.Dropped(); dropped > 0 {
+		fields = append(fields, Field{Name: "suppressed", Value: strconv.Itoa(dropped)})
+	}
+
+	t.notify.Send(Message{
+		Title:    filepath.Base(t.Path) + ": " + title,
+		Text:     strings.Join(pending.lines, "\n"),
+		Severity: pending.pattern.severity,
+		Fields:   fields,
+		Key:      key,
+	})
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttle 防止洗版：相同 key 在 window 內只放行一次，且每個 window 最多放行 burst 則
+type Throttle struct {
+	window time.Duration
+	burst  int
+
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	start   time.Time
+	count   int
+	dropped int
+}
+
+func NewThrottle(window time.Duration, burst int) *Throttle {
+	return &Throttle{
+		window: window,
+		burst:  burst,
+		seen:   map[string]time.Time{},
+	}
+}
+
+// Allow 回報 key 是否可以送出；burst 小於等於 0 時不限總量
+func (t *Throttle) Allow(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.start) >= t.window {
+		t.start = now
+		t.count = 0
+		for k, at := range t.seen {
+			if now.Sub(at) >= t.window {
+				delete(t.seen, k)
+			}
+		}
+	}
+
+	if at, ok := t.seen[key]; ok && now.Sub(at) < t.window {
+		t.dropped++
+		return false
+	}
+	if t.burst > 0 && t.count >= t.burst {
+		t.dropped++
+		return false
+	}
+
+	t.seen[key] = now
+	t.count++
+	return true
+}
+
+// Dropped 回傳並歸零目前為止被擋下的數量，可在下一則訊息附註
+func (t *Throttle) Dropped() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	dropped := t.dropped
+	t.dropped = 0
+	return dropped
+}
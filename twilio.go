@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type TwilioOption func(*twilio)
+
+// TwilioMessagingService 改用 Messaging Service 發送，此時可不指定 from
+func TwilioMessagingService(sid string) TwilioOption {
+	return func(t *twilio) { t.MessagingServiceSID = sid }
+}
+
+func (n *Notify) Twilio(accountSID, authToken, from string, to []string, opts ...TwilioOption) *Notify {
+	t := &twilio{
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+		From:       from,
+		To:         to,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	n.add(t)
+	return n
+}
+
+type twilio struct {
+	AccountSID          string
+	AuthToken           string
+	From                string
+	To                  []string
+	MessagingServiceSID string
+}
+
+func (t *twilio) Send(client *http.Client, message string) error {
+	// Twilio 單則訊息上限 1600 字
+	if r := []rune(message); len(r) > 1600 {
+		message = string(r[:1600])
+	}
+
+	var errs []error
+	for _, to := range t.To {
+		form := url.Values{}
+		form.Set("To", to)
+		form.Set("Body", message)
+		if err := t.post(client, form); err != nil {
+			errs = append(errs, fmt.Errorf("twilio %s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *twilio) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+
+	if form.Has("To") {
+		return t.post(client, form)
+	}
+
+	var errs []error
+	for _, to := range t.To {
+		form.Set("To", to)
+		if err := t.post(client, form); err != nil {
+			errs = append(errs, fmt.Errorf("twilio %s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *twilio) post(client *http.Client, form url.Values) error {
+	if !form.Has("From") && !form.Has("MessagingServiceSid") {
+		if t.MessagingServiceSID != "" {
+			form.Set("MessagingServiceSid", t.MessagingServiceSID)
+		} else {
+			form.Set("From", t.From)
+		}
+	}
+
+	url := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", t.AccountSID)
+	req, err := http.NewRequest("POST", url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.AccountSID, t.AuthToken)
+
+	return request(client, req)
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Twist threadID 不為 0 時以留言方式貼到既有討論串，否則每則訊息在 channelID 開新討論串
+func (n *Notify) Twist(token string, channelID, threadID int) *Notify {
+	n.add(&twist{
+		Token:     token,
+		ChannelID: channelID,
+		ThreadID:  threadID,
+	})
+	return n
+}
+
+type twist struct {
+	Token     string
+	ChannelID int
+	ThreadID  int
+}
+
+func (t *twist) Send(client *http.Client, message string) error {
+	if t.ThreadID != 0 {
+		return t.SendRaw(client, map[string]interface{}{"content": message})
+	}
+
+	title := subjectOf(message)
+	content := message
+	if _, rest, ok := strings.Cut(message, "\n"); ok {
+		content = rest
+	}
+	return t.SendRaw(client, map[string]interface{}{
+		"title":   title,
+		"content": content,
+	})
+}
+
+func (t *twist) SendMessage(client *http.Client, message Message) error {
+	var lines []string
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("- **%s**: %s", field.Name, field.Value))
+	}
+	if message.URL != "" {
+		lines = append(lines, message.URL)
+	}
+	content := strings.Join(lines, "\n")
+	title := fmt.Sprintf("[%s] %s", message.Severity, message.Title)
+
+	if t.ThreadID != 0 {
+		return t.SendRaw(client, map[string]interface{}{"content": "**" + title + "**\n" + content})
+	}
+	return t.SendRaw(client, map[string]interface{}{
+		"title":   title,
+		"content": content,
+	})
+}
+
+func (t *twist) SendRaw(client *http.Client, message map[string]interface{}) error {
+	url := "https://api.twist.com/api/v3/threads/add"
+	if _, ok := message["thread_id"]; ok {
+		url = "https://api.twist.com/api/v3/comments/add"
+	} else if _, ok := message["channel_id"]; !ok {
+		if t.ThreadID != 0 {
+			message["thread_id"] = t.ThreadID
+			url = "https://api.twist.com/api/v3/comments/add"
+		} else {
+			message["channel_id"] = t.ChannelID
+		}
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+
+	return request(client, req)
+}
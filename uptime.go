@@ -0,0 +1,231 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UptimeOption func(*Uptime)
+
+// UptimeInterval 檢查間隔，預設 1 分鐘
+func UptimeInterval(interval time.Duration) UptimeOption {
+	return func(u *Uptime) { u.Interval = interval }
+}
+
+// UptimeStatus 視為正常的狀態碼，預設 200-399
+func UptimeStatus(codes ...int) UptimeOption {
+	return func(u *Uptime) { u.Status = codes }
+}
+
+// UptimeContains 回應內容必須包含的字串
+func UptimeContains(substr string) UptimeOption {
+	return func(u *Uptime) { u.Contains = substr }
+}
+
+// UptimeLatency 回應時間超過 threshold 時發出 Warning，恢復時送出 Resolved
+func UptimeLatency(threshold time.Duration) UptimeOption {
+	return func(u *Uptime) { u.Latency = threshold }
+}
+
+// UptimeTimeout 單次請求的逾時，預設 10 秒
+func UptimeTimeout(timeout time.Duration) UptimeOption {
+	return func(u *Uptime) { u.Timeout = timeout }
+}
+
+// UptimeFailures 連續失敗幾次才視為 down，預設 2 次
+func UptimeFailures(count int) UptimeOption {
+	return func(u *Uptime) { u.Failures = count }
+}
+
+// Uptime 定期檢查 URL，down/恢復與回應過慢時通知，狀態碼、內容與回應時間附在訊息中
+func (n *Notify) Uptime(url string, opts ...UptimeOption) *Uptime {
+	u := &Uptime{
+		URL:      url,
+		Interval: time.Minute,
+		Timeout:  10 * time.Second,
+		Failures: 2,
+		notify:   n,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+type Uptime struct {
+	URL      string
+	Interval time.Duration
+	Status   []int
+	Contains string
+	Latency  time.Duration
+	Timeout  time.Duration
+	Failures int
+
+	notify *Notify
+
+	failures  int
+	down      bool
+	downSince time.Time
+	slowCount int
+	slow      bool
+}
+
+type uptimeResult struct {
+	status  int
+	latency time.Duration
+	body    string
+	err     error
+}
+
+// Run 依間隔檢查直到 ctx 結束
+func (u *Uptime) Run(ctx context.Context) error {
+	ticker := time.NewTicker(u.Interval)
+	defer ticker.Stop()
+
+	for {
+		u.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 檢查一次並在狀態改變時通知，回傳本次的錯誤
+func (u *Uptime) Check(ctx context.Context) error {
+	result := u.probe(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if result.err != nil {
+		u.failures++
+		if !u.down && u.failures >= u.Failures {
+			u.down = true
+			u.downSince = time.Now()
+			u.notify.Send(Message{
+				Title:    "Down: " + u.URL,
+				Text:     result.err.Error(),
+				Severity: SeverityError,
+				Fields:   result.fields(u.failures),
+				Key:      "uptime/" + u.URL,
+			})
+		}
+		return result.err
+	}
+
+	u.failures = 0
+	if u.down {
+		u.down = false
+		u.notify.Send(Message{
+			Title:    "Up: " + u.URL,
+			Text:     "Down for " + time.Since(u.downSince).Round(time.Second).String(),
+			Severity: SeverityInfo,
+			Status:   StatusResolved,
+			Fields:   result.fields(0),
+			Key:      "uptime/" + u.URL,
+		})
+	}
+
+	if u.Latency > 0 {
+		if result.latency > u.Latency {
+			u.slowCount++
+			if !u.slow && u.slowCount >= u.Failures {
+				u.slow = true
+				u.notify.Send(Message{
+					Title:    "Slow: " + u.URL,
+					Text:     fmt.Sprintf("Response took %s (threshold %s)", result.latency.Round(time.Millisecond), u.Latency),
+					Severity: SeverityWarning,
+					Fields:   result.fields(0),
+					Key:      "uptime/" + u.URL + "/latency",
+				})
+			}
+		} else {
+			u.slowCount = 0
+			if u.slow {
+				u.slow = false
+				u.notify.Send(Message{
+					Title:    "Latency recovered: " + u.URL,
+					Severity: SeverityInfo,
+					Status:   StatusResolved,
+					Fields:   result.fields(0),
+					Key:      "uptime/" + u.URL + "/latency",
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (u *Uptime) probe(ctx context.Context) uptimeResult {
+	ctx, cancel := context.WithTimeout(ctx, u.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.URL, nil)
+	if err != nil {
+		return uptimeResult{err: fmt.Errorf("failed to create request: %v", err)}
+	}
+	req.Header.Set("User-Agent", "notify-go uptime")
+
+	start := time.Now()
+	resp, err := u.notify.Client.Do(req)
+	if err != nil {
+		return uptimeResult{latency: time.Since(start), err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	result := uptimeResult{
+		status:  resp.StatusCode,
+		latency: time.Since(start),
+		body:    string(body),
+	}
+	if err != nil {
+		result.err = fmt.Errorf("failed to read body: %v", err)
+		return result
+	}
+
+	if !u.expectedStatus(resp.StatusCode) {
+		result.err = fmt.Errorf("unexpected status: %s", resp.Status)
+	} else if u.Contains != "" && !strings.Contains(result.body, u.Contains) {
+		result.err = fmt.Errorf("response does not contain %q", u.Contains)
+	}
+	return result
+}
+
+func (u *Uptime) expectedStatus(code int) bool {
+	if len(u.Status) == 0 {
+		return code >= 200 && code < 400
+	}
+	for _, status := range u.Status {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (r uptimeResult) fields(failures int) []Field {
+	var fields []Field
+	if r.status != 0 {
+		fields = append(fields, Field{Name: "status", Value: strconv.Itoa(r.status)})
+	}
+	fields = append(fields, Field{Name: "latency", Value: r.latency.Round(time.Millisecond).String()})
+	if failures > 0 {
+		fields = append(fields, Field{Name: "failures", Value: strconv.Itoa(failures)})
+	}
+	if r.err != nil && r.body != "" {
+		body := strings.TrimSpace(r.body)
+		if r := []rune(body); len(r) > 300 {
+			body = string(r[:300]) + "…"
+		}
+		fields = append(fields, Field{Name: "response", Value: body})
+	}
+	return fields
+}
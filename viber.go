@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type ViberOption func(*viber)
+
+func ViberAvatar(url string) ViberOption {
+	return func(v *viber) { v.Avatar = url }
+}
+
+// Viber 接收者必須先訂閱過該 bot
+func (n *Notify) Viber(authToken, senderName string, receivers []string, opts ...ViberOption) *Notify {
+	v := &viber{
+		AuthToken:  authToken,
+		SenderName: senderName,
+		Receivers:  receivers,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	n.add(v)
+	return n
+}
+
+type viber struct {
+	AuthToken  string
+	SenderName string
+	Avatar     string
+	Receivers  []string
+}
+
+func (v *viber) Send(client *http.Client, message string) error {
+	return v.SendRaw(client, map[string]interface{}{
+		"type": "text",
+		"text": message,
+	})
+}
+
+// SendRaw 圖片訊息例如 {"type": "picture", "media": url, "text": 說明}
+func (v *viber) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["sender"]; !ok {
+		sender := map[string]interface{}{"name": v.SenderName}
+		if v.Avatar != "" {
+			sender["avatar"] = v.Avatar
+		}
+		message["sender"] = sender
+	}
+
+	if _, ok := message["receiver"]; ok {
+		return v.post(client, message)
+	}
+
+	var errs []error
+	for _, receiver := range v.Receivers {
+		if err := v.post(client, withField(message, "receiver", receiver)); err != nil {
+			errs = append(errs, fmt.Errorf("viber %s: %w", receiver, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (v *viber) post(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://chatapi.viber.com/pa/send_message", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Viber-Auth-Token", v.AuthToken)
+
+	var result struct {
+		Status        int    `json:"status"`
+		StatusMessage string `json:"status_message"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.Status != 0 {
+		return fmt.Errorf("viber responded with status %d: %s", result.Status, result.StatusMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (n *Notify) Vonage(apiKey, apiSecret, from string, to []string) *Notify {
+	n.add(&vonage{
+		APIKey:    apiKey,
+		APISecret: apiSecret,
+		From:      from,
+		To:        to,
+	})
+	return n
+}
+
+type vonage struct {
+	APIKey    string
+	APISecret string
+	From      string
+	To        []string
+}
+
+func (v *vonage) Send(client *http.Client, message string) error {
+	var errs []error
+	for _, to := range v.To {
+		form := url.Values{}
+		form.Set("to", to)
+		form.Set("text", message)
+		form.Set("type", "unicode")
+		if err := v.post(client, form); err != nil {
+			errs = append(errs, fmt.Errorf("vonage %s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (v *vonage) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+
+	if form.Has("to") {
+		return v.post(client, form)
+	}
+
+	var errs []error
+	for _, to := range v.To {
+		form.Set("to", to)
+		if err := v.post(client, form); err != nil {
+			errs = append(errs, fmt.Errorf("vonage %s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (v *vonage) post(client *http.Client, form url.Values) error {
+	form.Set("api_key", v.APIKey)
+	form.Set("api_secret", v.APISecret)
+	if !form.Has("from") {
+		form.Set("from", v.From)
+	}
+
+	req, err := http.NewRequest("POST", "https://rest.nexmo.com/sms/json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Vonage 一律回 200，實際結果在每段訊息的 status
+	var result struct {
+		Messages []struct {
+			Status    string `json:"status"`
+			ErrorText string `json:"error-text"`
+		} `json:"messages"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+
+	for _, m := range result.Messages {
+		if m.Status != "0" {
+			return fmt.Errorf("vonage responded with status %s: %s", m.Status, m.ErrorText)
+		}
+	}
+
+	return nil
+}
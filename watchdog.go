@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type WatchdogOption func(*Watchdog)
+
+// WatchdogGrace 超過 interval 後再寬限多久才告警，避免偶發延遲誤報
+func WatchdogGrace(grace time.Duration) WatchdogOption {
+	return func(w *Watchdog) { w.Grace = grace }
+}
+
+// WatchdogSeverity 告警的嚴重度，預設 Error
+func WatchdogSeverity(severity Severity) WatchdogOption {
+	return func(w *Watchdog) { w.Severity = severity }
+}
+
+// Watchdog 不需外部服務的 dead man's switch：呼叫端定期 Ping，超過 interval 沒有 Ping 即告警，
+// 恢復 Ping 時以相同 Key 送出 Resolved。建立後即開始計時
+func (n *Notify) Watchdog(name string, interval time.Duration, opts ...WatchdogOption) *Watchdog {
+	w := &Watchdog{
+		Name:     name,
+		Interval: interval,
+		Severity: SeverityError,
+		notify:   n,
+		lastPing: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.timer = time.AfterFunc(w.Interval+w.Grace, w.expired)
+	return w
+}
+
+type Watchdog struct {
+	Name     string
+	Interval time.Duration
+	Grace    time.Duration
+	Severity Severity
+
+	notify   *Notify
+	mu       sync.Mutex
+	timer    *time.Timer
+	lastPing time.Time
+	missed   bool
+	stopped  bool
+}
+
+// Ping 回報仍在運作，先前已告警時送出恢復通知
+func (w *Watchdog) Ping() {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return
+	}
+	missed := w.missed
+	down := time.Since(w.lastPing)
+	w.missed = false
+	w.lastPing = time.Now()
+	w.timer.Reset(w.Interval + w.Grace)
+	w.mu.Unlock()
+
+	if missed {
+		w.notify.Send(Message{
+			Title:    "Heartbeat recovered: " + w.Name,
+			Severity: SeverityInfo,
+			Status:   StatusResolved,
+			Fields:   []Field{{Name: "silent for", Value: down.Round(time.Second).String()}},
+			Key:      "watchdog/" + w.Name,
+		})
+	}
+}
+
+// Stop 停止監看，例如程式正常結束前呼叫，避免誤報
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stopped = true
+	w.timer.Stop()
+}
+
+func (w *Watchdog) expired() {
+	w.mu.Lock()
+	// Reset 與逾時同時發生時以最後一次 Ping 為準
+	if w.stopped || w.missed || time.Since(w.lastPing) < w.Interval+w.Grace {
+		w.mu.Unlock()
+		return
+	}
+	w.missed = true
+	lastPing := w.lastPing
+	w.mu.Unlock()
+
+	w.notify.Send(Message{
+		Title:    "Heartbeat missed: " + w.Name,
+		Text:     fmt.Sprintf("No heartbeat for %s (expected every %s).", time.Since(lastPing).Round(time.Second), w.Interval),
+		Severity: w.Severity,
+		Fields:   []Field{{Name: "last ping", Value: lastPing.Format(time.RFC3339)}},
+		Key:      "watchdog/" + w.Name,
+	})
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+type WebexOption func(*webex)
+
+func WebexMarkdown() WebexOption {
+	return func(w *webex) { w.Markdown = true }
+}
+
+// Webex target 為 roomId 或收件人 email
+func (n *Notify) Webex(botToken, target string, opts ...WebexOption) *Notify {
+	w := &webex{BotToken: botToken}
+	if strings.Contains(target, "@") {
+		w.PersonEmail = target
+	} else {
+		w.RoomID = target
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+type webex struct {
+	BotToken    string
+	RoomID      string
+	PersonEmail string
+	Markdown    bool
+}
+
+func (w *webex) Send(client *http.Client, message string) error {
+	if w.Markdown {
+		return w.SendRaw(client, map[string]interface{}{"markdown": message})
+	}
+	return w.SendRaw(client, map[string]interface{}{"text": message})
+}
+
+func (w *webex) SendMessage(client *http.Client, message Message) error {
+	var lines []string
+	if title := message.heading(); title != "" {
+		lines = append(lines, "**"+title+"**")
+	}
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("- **%s**: %s", field.Name, field.Value))
+	}
+	if message.URL != "" {
+		lines = append(lines, fmt.Sprintf("[查看詳情](%s)", message.URL))
+	}
+
+	payload := map[string]interface{}{
+		"markdown": strings.Join(lines, "\n\n"),
+		"text":     message.String(),
+	}
+	// 每則訊息只能附一個檔案，其餘附件分開送出
+	attachments := message.Attachments
+	if len(attachments) > 0 {
+		payload["files"] = attachments[0].Data
+		payload["filename"] = attachments[0].Name
+		attachments = attachments[1:]
+	}
+	if err := w.SendRaw(client, payload); err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := w.SendRaw(client, map[string]interface{}{
+			"files":    attachment.Data,
+			"filename": attachment.Name,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendRaw files 欄位為 []byte 時以 multipart 上傳附件，檔名取 filename 欄位
+func (w *webex) SendRaw(client *http.Client, message map[string]interface{}) error {
+	_, hasRoom := message["roomId"]
+	_, hasPerson := message["toPersonEmail"]
+	if !hasRoom && !hasPerson {
+		if w.RoomID != "" {
+			message["roomId"] = w.RoomID
+		} else {
+			message["toPersonEmail"] = w.PersonEmail
+		}
+	}
+
+	var req *http.Request
+	if file, ok := message["files"].([]byte); ok {
+		filename, _ := message["filename"].(string)
+		if filename == "" {
+			filename = "attachment"
+		}
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for key, value := range message {
+			if key != "files" && key != "filename" {
+				writer.WriteField(key, fmt.Sprint(value))
+			}
+		}
+		part, err := writer.CreateFormFile("files", filename)
+		if err != nil {
+			return fmt.Errorf("failed to create multipart: %v", err)
+		}
+		if _, err := part.Write(file); err != nil {
+			return fmt.Errorf("failed to write multipart: %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("failed to close multipart: %v", err)
+		}
+
+		req, err = http.NewRequest("POST", "https://webexapis.com/v1/messages", body)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+	} else {
+		jsonData, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+
+		req, err = http.NewRequest("POST", "https://webexapis.com/v1/messages", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+w.BotToken)
+
+	return request(client, req)
+}
@@ -0,0 +1,228 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type WebhookOption func(*webhook)
+
+func WebhookMethod(method string) WebhookOption {
+	return func(w *webhook) { w.Method = method }
+}
+
+func WebhookHeader(key, value string) WebhookOption {
+	return func(w *webhook) { w.Headers.Set(key, value) }
+}
+
+// WebhookTemplate 以 text/template 產生 JSON body，資料為 Message，
+// 字串請用 json 函式輸出以確保跳脫，例如 {"text": {{json .Text}}}
+func WebhookTemplate(tmpl string) WebhookOption {
+	return func(w *webhook) {
+		w.Template, w.err = template.New("webhook").Funcs(webhookFuncs).Parse(tmpl)
+	}
+}
+
+// WebhookForm 改以 form 送出，每個欄位值都是以 Message 為資料的 text/template
+func WebhookForm(fields map[string]string) WebhookOption {
+	return func(w *webhook) {
+		w.Form = map[string]*template.Template{}
+		for key, tmpl := range fields {
+			t, err := template.New(key).Funcs(webhookFuncs).Parse(tmpl)
+			if err != nil {
+				w.err = err
+				return
+			}
+			w.Form[key] = t
+		}
+	}
+}
+
+// WebhookSigning 以 HMAC-SHA256 簽署 body，header 格式為 "t=<unix 秒>,v1=<hex>"，
+// 簽署內容為 "<t>.<body>"；header 留空時使用 X-Notify-Signature
+func WebhookSigning(secret, header string) WebhookOption {
+	return func(w *webhook) {
+		if header == "" {
+			header = "X-Notify-Signature"
+		}
+		w.SigningSecret = secret
+		w.SignatureHeader = header
+	}
+}
+
+// VerifyWebhookSignature 供接收端驗證 WebhookSigning 產生的簽章，
+// tolerance 為可接受的時間差，避免重放攻擊；為 0 時不檢查時間
+func VerifyWebhookSignature(secret, signature string, body []byte, tolerance time.Duration) error {
+	// 更換金鑰期間 (例如 Stripe) 會帶多個 v1，任一個符合即可
+	var timestamp string
+	var candidates []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			candidates = append(candidates, value)
+		}
+	}
+	if timestamp == "" || len(candidates) == 0 {
+		return errors.New("invalid webhook signature header")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook signature timestamp")
+	}
+	if tolerance > 0 {
+		age := time.Since(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return errors.New("webhook signature timestamp outside tolerance")
+		}
+	}
+
+	expected := webhookSignature(secret, timestamp, body)
+	for _, candidate := range candidates {
+		given, err := hex.DecodeString(candidate)
+		if err == nil && hmac.Equal(given, expected) {
+			return nil
+		}
+	}
+	return errors.New("webhook signature mismatch")
+}
+
+func webhookSignature(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+var webhookFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// Webhook 通用 HTTP webhook，未指定樣板時送出 Message 的 JSON
+func (n *Notify) Webhook(url string, opts ...WebhookOption) *Notify {
+	w := &webhook{
+		URL:     url,
+		Method:  "POST",
+		Headers: http.Header{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+type webhook struct {
+	URL      string
+	Method   string
+	Headers  http.Header
+	Template *template.Template
+	Form     map[string]*template.Template
+
+	SigningSecret   string
+	SignatureHeader string
+
+	err error
+}
+
+func (w *webhook) Send(client *http.Client, message string) error {
+	return w.SendMessage(client, Message{Text: message})
+}
+
+func (w *webhook) SendMessage(client *http.Client, message Message) error {
+	if w.err != nil {
+		return fmt.Errorf("invalid webhook template: %v", w.err)
+	}
+
+	var body []byte
+	contentType := "application/json"
+
+	switch {
+	case w.Form != nil:
+		form := url.Values{}
+		for key, t := range w.Form {
+			var buf bytes.Buffer
+			if err := t.Execute(&buf, message); err != nil {
+				return fmt.Errorf("failed to render webhook template: %v", err)
+			}
+			form.Set(key, buf.String())
+		}
+		body = []byte(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+
+	case w.Template != nil:
+		var buf bytes.Buffer
+		if err := w.Template.Execute(&buf, message); err != nil {
+			return fmt.Errorf("failed to render webhook template: %v", err)
+		}
+		body = buf.Bytes()
+
+	default:
+		fields := map[string]string{}
+		for _, field := range message.Fields {
+			fields[field.Name] = field.Value
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"title":    message.Title,
+			"text":     message.Text,
+			"severity": strings.ToLower(message.Severity.String()),
+			"status":   strings.ToLower(message.Status.String()),
+			"fields":   fields,
+			"url":      message.URL,
+			"key":      message.Key,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %v", err)
+		}
+		body = jsonData
+	}
+
+	return w.do(client, body, contentType)
+}
+
+func (w *webhook) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	return w.do(client, jsonData, "application/json")
+}
+
+func (w *webhook) do(client *http.Client, body []byte, contentType string) error {
+	req, err := http.NewRequest(w.Method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for key, values := range w.Headers {
+		req.Header[key] = values
+	}
+	if w.SigningSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		signature := hex.EncodeToString(webhookSignature(w.SigningSecret, timestamp, body))
+		req.Header.Set(w.SignatureHeader, "t="+timestamp+",v1="+signature)
+	}
+
+	return request(client, req)
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignatureMultipleV1(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	valid := hex.EncodeToString(webhookSignature("new-secret", timestamp, body))
+	stale := hex.EncodeToString(webhookSignature("old-secret", timestamp, body))
+
+	for _, header := range []string{
+		"t=" + timestamp + ",v1=" + valid + ",v1=" + stale,
+		"t=" + timestamp + ",v1=" + stale + ",v1=" + valid,
+		"t=" + timestamp + ",v1=zz,v1=" + valid,
+	} {
+		if err := VerifyWebhookSignature("new-secret", header, body, time.Minute); err != nil {
+			t.Errorf("%s: %v", header, err)
+		}
+	}
+
+	if err := VerifyWebhookSignature("new-secret", "t="+timestamp+",v1="+stale, body, time.Minute); err == nil {
+		t.Error("stale signature accepted")
+	}
+	if err := VerifyWebhookSignature("new-secret", "t="+timestamp, body, time.Minute); err == nil {
+		t.Error("header without v1 accepted")
+	}
+}
@@ -0,0 +1,267 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebPushSubscription 對應瀏覽器 PushSubscription.toJSON() 的內容
+type WebPushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+type WebPushOption func(*webpush)
+
+func WebPushTTL(ttl time.Duration) WebPushOption {
+	return func(w *webpush) { w.TTL = ttl }
+}
+
+// WebPushUrgency very-low、low、normal 或 high
+func WebPushUrgency(urgency string) WebPushOption {
+	return func(w *webpush) { w.Urgency = urgency }
+}
+
+// WebPush vapidPrivateKey 為 base64url 編碼的 32 bytes 私鑰，subject 為 mailto: 或 https: 聯絡方式
+func (n *Notify) WebPush(vapidPrivateKey, subject string, subscriptions []WebPushSubscription, opts ...WebPushOption) *Notify {
+	w := &webpush{
+		Subject:       subject,
+		Subscriptions: subscriptions,
+		TTL:           24 * time.Hour,
+	}
+	w.Key, w.err = parseVAPIDKey(vapidPrivateKey)
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+type webpush struct {
+	Key           *ecdsa.PrivateKey
+	Subject       string
+	Subscriptions []WebPushSubscription
+	TTL           time.Duration
+	Urgency       string
+
+	err error
+}
+
+func parseVAPIDKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	d, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(privateKey, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %v", err)
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %v", err)
+	}
+
+	public := key.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func (w *webpush) Send(client *http.Client, message string) error {
+	return w.SendRaw(client, map[string]interface{}{
+		"title": subjectOf(message),
+		"body":  message,
+	})
+}
+
+func (w *webpush) SendMessage(client *http.Client, message Message) error {
+	payload := map[string]interface{}{
+		"title":    message.Title,
+		"body":     message.Text,
+		"severity": message.Severity.String(),
+	}
+	if message.URL != "" {
+		payload["url"] = message.URL
+	}
+	if message.Key != "" {
+		payload["tag"] = message.Key
+	}
+
+	return w.SendRaw(client, payload)
+}
+
+// SendRaw payload 以 JSON 加密後送出，由 Service Worker 的 push 事件解析
+func (w *webpush) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	if len(jsonData) > webPushMaxPayload {
+		return fmt.Errorf("webpush payload is %d bytes, exceeds the %d byte limit", len(jsonData), webPushMaxPayload)
+	}
+
+	var errs []error
+	for _, subscription := range w.Subscriptions {
+		if err := w.push(client, subscription, jsonData); err != nil {
+			errs = append(errs, fmt.Errorf("webpush %s: %w", subscription.Endpoint, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *webpush) push(client *http.Client, subscription WebPushSubscription, payload []byte) error {
+	body, err := webPushEncrypt(subscription, payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %v", err)
+	}
+
+	jwt, err := signJWT(map[string]interface{}{}, map[string]interface{}{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": w.Subject,
+	}, w.Key)
+	if err != nil {
+		return err
+	}
+	ecdhKey, err := w.Key.ECDH()
+	if err != nil {
+		return fmt.Errorf("invalid VAPID private key: %v", err)
+	}
+	publicKey := ecdhKey.PublicKey().Bytes()
+
+	req, err := http.NewRequest("POST", subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(w.TTL.Seconds())))
+	req.Header.Set("Authorization", "vapid t="+jwt+", k="+base64.RawURLEncoding.EncodeToString(publicKey))
+	if w.Urgency != "" {
+		req.Header.Set("Urgency", w.Urgency)
+	}
+
+	return request(client, req)
+}
+
+// webPushRecordSize 宣告的 record size；RFC 8291 §4 限制整個 body 不超過 4096 bytes，
+// 扣除 86 bytes 的 header、16 bytes 的 tag 與 1 byte 的結尾標記後即為明文上限
+const (
+	webPushRecordSize = 4096
+	webPushMaxPayload = 4096 - 86 - 16 - 1
+)
+
+// webPushEncrypt 依 RFC 8291 以 aes128gcm (RFC 8188) 加密 payload，每次使用新的 key pair 與 salt
+func webPushEncrypt(subscription WebPushSubscription, payload []byte) ([]byte, error) {
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+	return webPushEncryptWith(subscription, payload, asPrivate, salt)
+}
+
+func webPushEncryptWith(subscription WebPushSubscription, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > webPushMaxPayload {
+		return nil, fmt.Errorf("webpush payload is %d bytes, exceeds the %d byte limit", len(payload), webPushMaxPayload)
+	}
+
+	uaPublicData, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.Keys.P256dh, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %v", err)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(subscription.Keys.Auth, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %v", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %v", err)
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %v", err)
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicData...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfSHA256(authSecret, ecdhSecret, keyInfo, 32)
+
+	cek := hkdfSHA256(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfSHA256(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	// 單一 record，結尾以 0x02 標示最後一段
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	// header: salt(16) || rs(4) || idlen(1) || keyid(as_public)
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return append(header, ciphertext...), nil
+}
+
+// hkdfSHA256 RFC 5869，輸出長度不超過 32 bytes
+func hkdfSHA256(salt, secret, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func mustBase64URL(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// TestWebPushEncryptRFC8291 RFC 8291 Appendix A 的範例
+func TestWebPushEncryptRFC8291(t *testing.T) {
+	var subscription WebPushSubscription
+	subscription.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	subscription.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	asPrivate, err := ecdh.P256().NewPrivateKey(mustBase64URL(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt := mustBase64URL(t, "DGv6ra1nlYgDCS1FRnbzlw")
+
+	body, err := webPushEncryptWith(subscription, []byte("When I grow up, I want to be a watermelon"), asPrivate, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	if got := base64.RawURLEncoding.EncodeToString(body); got != want {
+		t.Fatalf("body =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWebPushPayloadLimit(t *testing.T) {
+	var subscription WebPushSubscription
+	subscription.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	subscription.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	body, err := webPushEncrypt(subscription, make([]byte, webPushMaxPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 8291 §4：header 與 record 合計剛好 4096 bytes
+	if len(body) != 4096 {
+		t.Fatalf("body is %d bytes, want 4096", len(body))
+	}
+
+	if _, err := webPushEncrypt(subscription, make([]byte, webPushMaxPayload+1)); err == nil {
+		t.Fatal("expected error for oversized payload")
+	}
+}
+
+func TestWebPushSend(t *testing.T) {
+	var headers http.Header
+	var size int
+	n, done := testNotify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		buf := new(strings.Builder)
+		io.Copy(buf, r.Body)
+		size = buf.Len()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer done()
+
+	var subscription WebPushSubscription
+	subscription.Endpoint = "https://push.example.com/send/abc"
+	subscription.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	subscription.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	n.WebPush("q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94", "mailto:ops@example.com", []WebPushSubscription{subscription}, WebPushUrgency("high"))
+	if err := n.Send("disk full"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := headers.Get("Content-Encoding"); got != "aes128gcm" {
+		t.Errorf("Content-Encoding = %q", got)
+	}
+	if got := headers.Get("TTL"); got != "86400" {
+		t.Errorf("TTL = %q", got)
+	}
+	if got := headers.Get("Urgency"); got != "high" {
+		t.Errorf("Urgency = %q", got)
+	}
+	if got := headers.Get("Authorization"); !strings.HasPrefix(got, "vapid t=") || !strings.Contains(got, ", k=") {
+		t.Errorf("Authorization = %q", got)
+	}
+	if size <= 86 {
+		t.Errorf("body is %d bytes", size)
+	}
+
+	if err := n.Send(strings.Repeat("x", webPushRecordSize)); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("oversized payload error = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// wsConn 精簡的 WebSocket (RFC 6455) client，只處理文字訊息
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialWebSocket(rawURL string, timeout time.Duration) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid websocket url: %v", err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "wss":
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	case "ws":
+		conn, err = dialer.Dial("tcp", host)
+	default:
+		return nil, fmt.Errorf("unsupported websocket scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect %s: %v", u.Host, err)
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	path := u.RequestURI()
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n", path, u.Host, key)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read websocket handshake: %v", err)
+	}
+	resp.Body.Close()
+
+	accept := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(accept[:]) {
+		conn.Close()
+		return nil, fmt.Errorf("websocket handshake failed: %v", resp.Status)
+	}
+
+	return &wsConn{conn: conn, reader: reader}, nil
+}
+
+func (ws *wsConn) WriteText(data []byte) error {
+	return ws.writeFrame(0x1, data)
+}
+
+func (ws *wsConn) writeFrame(opcode byte, data []byte) error {
+	frame := []byte{0x80 | opcode}
+
+	// client 送出的 frame 必須加上 mask
+	switch {
+	case len(data) < 126:
+		frame = append(frame, 0x80|byte(len(data)))
+	case len(data) <= 0xFFFF:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(data)))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(data)))
+	}
+
+	mask := make([]byte, 4)
+	rand.Read(mask)
+	frame = append(frame, mask...)
+	for i, b := range data {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := ws.conn.Write(frame)
+	return err
+}
+
+// ReadText 讀取下一則文字訊息，期間自動回應 ping
+func (ws *wsConn) ReadText() ([]byte, error) {
+	var message []byte
+	for {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(ws.reader, header); err != nil {
+			return nil, err
+		}
+
+		fin := header[0]&0x80 != 0
+		opcode := header[0] & 0x0F
+		length := uint64(header[1] & 0x7F)
+		switch length {
+		case 126:
+			ext := make([]byte, 2)
+			if _, err := io.ReadFull(ws.reader, ext); err != nil {
+				return nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext))
+		case 127:
+			ext := make([]byte, 8)
+			if _, err := io.ReadFull(ws.reader, ext); err != nil {
+				return nil, err
+			}
+			length = binary.BigEndian.Uint64(ext)
+		}
+		if length > 16<<20 {
+			return nil, errors.New("websocket frame too large")
+		}
+
+		var mask []byte
+		if header[1]&0x80 != 0 {
+			mask = make([]byte, 4)
+			if _, err := io.ReadFull(ws.reader, mask); err != nil {
+				return nil, err
+			}
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(ws.reader, payload); err != nil {
+			return nil, err
+		}
+		if mask != nil {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch opcode {
+		case 0x8:
+			return nil, errors.New("websocket closed by server")
+		case 0x9:
+			if err := ws.writeFrame(0xA, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case 0xA:
+			continue
+		}
+
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (ws *wsConn) SetDeadline(t time.Time) error {
+	return ws.conn.SetDeadline(t)
+}
+
+func (ws *wsConn) Close() error {
+	ws.writeFrame(0x8, []byte{0x03, 0xE8})
+	return ws.conn.Close()
+}
@@ -0,0 +1,255 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type WeComOption func(*wecom)
+
+func WeComMarkdown() WeComOption {
+	return func(w *wecom) { w.Markdown = true }
+}
+
+// WeComMentions 提醒指定成員，"@all" 表示所有人（僅 text 類型有效）
+func WeComMentions(userIDs ...string) WeComOption {
+	return func(w *wecom) { w.Mentions = append(w.Mentions, userIDs...) }
+}
+
+func WeComMentionMobiles(mobiles ...string) WeComOption {
+	return func(w *wecom) { w.MentionMobiles = append(w.MentionMobiles, mobiles...) }
+}
+
+// WeComParty 應用訊息發送對象改為部門，可與 toUser 同時使用
+func WeComParty(partyIDs ...string) WeComOption {
+	return func(w *wecom) { w.ToParty = strings.Join(partyIDs, "|") }
+}
+
+// WeCom 群機器人
+func (n *Notify) WeCom(webhookKey string, opts ...WeComOption) *Notify {
+	w := &wecom{WebhookKey: webhookKey}
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+// WeComApp 自建應用訊息，toUser 以 "|" 分隔多個成員或 "@all"
+func (n *Notify) WeComApp(corpID, corpSecret string, agentID int, toUser string, opts ...WeComOption) *Notify {
+	w := &wecom{
+		CorpID:     corpID,
+		CorpSecret: corpSecret,
+		AgentID:    agentID,
+		ToUser:     toUser,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+type wecom struct {
+	WebhookKey     string
+	CorpID         string
+	CorpSecret     string
+	AgentID        int
+	ToUser         string
+	ToParty        string
+	Markdown       bool
+	Mentions       []string
+	MentionMobiles []string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func (w *wecom) Send(client *http.Client, message string) error {
+	if w.Markdown {
+		return w.SendRaw(client, map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]interface{}{"content": message},
+		})
+	}
+
+	text := map[string]interface{}{"content": message}
+	if len(w.Mentions) > 0 {
+		text["mentioned_list"] = w.Mentions
+	}
+	if len(w.MentionMobiles) > 0 {
+		text["mentioned_mobile_list"] = w.MentionMobiles
+	}
+
+	return w.SendRaw(client, map[string]interface{}{
+		"msgtype": "text",
+		"text":    text,
+	})
+}
+
+func (w *wecom) SendMessage(client *http.Client, message Message) error {
+	heading := message.heading()
+
+	if message.URL != "" {
+		title := heading
+		if title == "" {
+			title = subjectOf(message.Text)
+		}
+		var description []string
+		if message.Text != "" {
+			description = append(description, message.Text)
+		}
+		for _, field := range message.Fields {
+			description = append(description, field.Name+": "+field.Value)
+		}
+		return w.SendRaw(client, map[string]interface{}{
+			"msgtype": "news",
+			"news": map[string]interface{}{
+				"articles": []map[string]interface{}{{
+					"title":       title,
+					"description": strings.Join(description, "\n"),
+					"url":         message.URL,
+				}},
+			},
+		})
+	}
+
+	var lines []string
+	if heading != "" {
+		lines = append(lines, fmt.Sprintf(`### <font color="%s">%s</font>`, wecomColor(message), heading))
+	}
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("> %s: <font color=\"comment\">%s</font>", field.Name, field.Value))
+	}
+
+	return w.SendRaw(client, map[string]interface{}{
+		"msgtype":  "markdown",
+		"markdown": map[string]interface{}{"content": strings.Join(lines, "\n")},
+	})
+}
+
+// wecomColor WeCom markdown 只支援 info (綠)、comment (灰)、warning (橘紅)，依 severityColor 對應
+func wecomColor(message Message) string {
+	switch severityColor(message) {
+	case "#2eb886":
+		return "info"
+	case "#439fe0":
+		return "comment"
+	default:
+		return "warning"
+	}
+}
+
+// SendRaw msgtype 為 image 且 image 欄位是 []byte 時，自動計算 base64 與 md5
+func (w *wecom) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if data, ok := message["image"].([]byte); ok {
+		sum := md5.Sum(data)
+		message["msgtype"] = "image"
+		message["image"] = map[string]interface{}{
+			"base64": base64.StdEncoding.EncodeToString(data),
+			"md5":    hex.EncodeToString(sum[:]),
+		}
+	}
+
+	if w.WebhookKey != "" {
+		url := "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=" + url.QueryEscape(w.WebhookKey)
+		return w.post(client, url, message)
+	}
+
+	if _, ok := message["agentid"]; !ok {
+		message["agentid"] = w.AgentID
+	}
+	if _, ok := message["touser"]; !ok && w.ToUser != "" {
+		message["touser"] = w.ToUser
+	}
+	if _, ok := message["toparty"]; !ok && w.ToParty != "" {
+		message["toparty"] = w.ToParty
+	}
+
+	token, err := w.token(client)
+	if err != nil {
+		return err
+	}
+
+	err = w.post(client, "https://qyapi.weixin.qq.com/cgi-bin/message/send?access_token="+url.QueryEscape(token), message)
+	if err != nil {
+		// token 可能已被其他程式刷新而失效，清除快取讓下次重新取得
+		w.mu.Lock()
+		w.accessToken = ""
+		w.mu.Unlock()
+	}
+	return err
+}
+
+func (w *wecom) token(client *http.Client) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.accessToken != "" && time.Now().Before(w.expiresAt) {
+		return w.accessToken, nil
+	}
+
+	query := url.Values{}
+	query.Set("corpid", w.CorpID)
+	query.Set("corpsecret", w.CorpSecret)
+	req, err := http.NewRequest("GET", "https://qyapi.weixin.qq.com/cgi-bin/gettoken?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var result struct {
+		ErrCode     int    `json:"errcode"`
+		ErrMsg      string `json:"errmsg"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", err
+	}
+	if result.ErrCode != 0 {
+		return "", fmt.Errorf("wecom gettoken responded with errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+
+	w.accessToken = result.AccessToken
+	w.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - 5*time.Minute)
+	return w.accessToken, nil
+}
+
+func (w *wecom) post(client *http.Client, url string, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return err
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("wecom responded with errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+
+	return nil
+}
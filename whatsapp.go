@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type WhatsAppOption func(*whatsapp)
+
+// WhatsAppTemplate 超過 24 小時對話時窗只能發送已審核的樣板，訊息會作為樣板 body 的第一個參數
+func WhatsAppTemplate(name, languageCode string) WhatsAppOption {
+	return func(w *whatsapp) {
+		w.Template = name
+		w.Language = languageCode
+	}
+}
+
+func (n *Notify) WhatsApp(phoneNumberID, accessToken string, to []string, opts ...WhatsAppOption) *Notify {
+	w := &whatsapp{
+		PhoneNumberID: phoneNumberID,
+		AccessToken:   accessToken,
+		To:            to,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	n.add(w)
+	return n
+}
+
+type whatsapp struct {
+	PhoneNumberID string
+	AccessToken   string
+	To            []string
+	Template      string
+	Language      string
+}
+
+func (w *whatsapp) Send(client *http.Client, message string) error {
+	if w.Template != "" {
+		return w.SendRaw(client, map[string]interface{}{
+			"type": "template",
+			"template": map[string]interface{}{
+				"name":     w.Template,
+				"language": map[string]interface{}{"code": w.Language},
+				"components": []map[string]interface{}{{
+					"type": "body",
+					"parameters": []map[string]interface{}{{
+						"type": "text",
+						"text": message,
+					}},
+				}},
+			},
+		})
+	}
+
+	return w.SendRaw(client, map[string]interface{}{
+		"type": "text",
+		"text": map[string]interface{}{"body": message},
+	})
+}
+
+func (w *whatsapp) SendRaw(client *http.Client, message map[string]interface{}) error {
+	message["messaging_product"] = "whatsapp"
+
+	if _, ok := message["to"]; ok {
+		return w.post(client, message)
+	}
+
+	var errs []error
+	for _, to := range w.To {
+		if err := w.post(client, withField(message, "to", to)); err != nil {
+			errs = append(errs, fmt.Errorf("whatsapp %s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *whatsapp) post(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://graph.facebook.com/v19.0/%s/messages", w.PhoneNumberID)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+w.AccessToken)
+
+	return request(client, req)
+}
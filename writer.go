@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type WriterOption func(*writer)
+
+// WriterInterval 第一行寫入後最多等待多久送出，預設 2 秒
+func WriterInterval(interval time.Duration) WriterOption {
+	return func(w *writer) { w.Interval = interval }
+}
+
+// WriterMaxLines 累積幾行就立即送出，預設 20 行
+func WriterMaxLines(lines int) WriterOption {
+	return func(w *writer) { w.MaxLines = lines }
+}
+
+// WriterSeverity 以結構化訊息送出並設定嚴重度，title 為訊息標題
+func WriterSeverity(title string, severity Severity) WriterOption {
+	return func(w *writer) {
+		w.Title = title
+		w.Severity = &severity
+	}
+}
+
+// Writer 將寫入的內容逐行累積後批次送出，可接在既有的 log 輸出，例如 log.SetOutput(n.Writer())；
+// Close 會送出剩餘內容
+func (n *Notify) Writer(opts ...WriterOption) io.WriteCloser {
+	w := &writer{
+		notify:   n,
+		Interval: 2 * time.Second,
+		MaxLines: 20,
+		MaxBytes: 3500,
+	}
+	w.idle = sync.NewCond(&w.mu)
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+type writer struct {
+	Interval time.Duration
+	MaxLines int
+	MaxBytes int
+	Title    string
+	Severity *Severity
+
+	notify  *Notify
+	mu      sync.Mutex
+	idle    *sync.Cond
+	partial []byte
+	lines   []string
+	size    int
+	timer   *time.Timer
+	pending []string
+	sending bool
+}
+
+// Write 只整理批次，實際送出交給背景 goroutine 依序處理；
+// log.Logger 寫入時持有自己的鎖，若在這裡同步送出，notifier 失敗時的 log 輸出會卡死
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.partial[:i]), "\r")
+		w.partial = w.partial[i+1:]
+		// notify 自身的送出錯誤不再轉送，避免通道失敗時無限循環
+		if line == "" || strings.Contains(line, "notify send error") {
+			continue
+		}
+
+		// 單行加入後超過上限時先送出目前累積的內容
+		if len(w.lines) > 0 && w.size+len(line) > w.MaxBytes {
+			w.enqueueLocked()
+		}
+		w.lines = append(w.lines, line)
+		w.size += len(line) + 1
+
+		if len(w.lines) >= w.MaxLines || w.size >= w.MaxBytes {
+			w.enqueueLocked()
+		} else if w.timer == nil {
+			w.timer = time.AfterFunc(w.Interval, w.flush)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close 等待背景送出完成，再同步送出尚未換行的內容與累積的批次
+func (w *writer) Close() error {
+	w.mu.Lock()
+	if line := strings.TrimSpace(string(w.partial)); line != "" {
+		w.lines = append(w.lines, line)
+	}
+	w.partial = nil
+	text := w.takeLocked()
+	for w.sending {
+		w.idle.Wait()
+	}
+	w.mu.Unlock()
+
+	return w.send(text)
+}
+
+func (w *writer) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.enqueueLocked()
+}
+
+// takeLocked 取出目前累積的內容並清空，需持有 w.mu
+func (w *writer) takeLocked() string {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	text := strings.Join(w.lines, "\n")
+	w.lines = nil
+	w.size = 0
+	return text
+}
+
+// enqueueLocked 將目前累積的內容排入背景送出，需持有 w.mu
+func (w *writer) enqueueLocked() {
+	text := w.takeLocked()
+	if text == "" {
+		return
+	}
+	w.pending = append(w.pending, text)
+	if !w.sending {
+		w.sending = true
+		go w.drain()
+	}
+}
+
+func (w *writer) drain() {
+	for {
+		w.mu.Lock()
+		if len(w.pending) == 0 {
+			w.sending = false
+			w.idle.Broadcast()
+			w.mu.Unlock()
+			return
+		}
+		text := w.pending[0]
+		w.pending = w.pending[1:]
+		w.mu.Unlock()
+
+		w.send(text)
+	}
+}
+
+func (w *writer) send(text string) error {
+	if text == "" {
+		return nil
+	}
+	if w.Severity != nil {
+		return w.notify.Send(Message{
+			Title:    w.Title,
+			Text:     text,
+			Severity: *w.Severity,
+		})
+	}
+	return w.notify.Send(text)
+}
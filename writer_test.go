@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordNotifier struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (r *recordNotifier) Send(_ *http.Client, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, message)
+	return r.err
+}
+
+func (r *recordNotifier) SendRaw(_ *http.Client, _ map[string]interface{}) error {
+	return r.err
+}
+
+func (r *recordNotifier) messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sent...)
+}
+
+func TestWriterLogOutputWithFailingNotifier(t *testing.T) {
+	failing := &recordNotifier{err: errors.New("boom")}
+	n := New()
+	n.Notifiers = append(n.Notifiers, failing)
+
+	w := n.Writer(WriterMaxLines(1), WriterInterval(time.Hour))
+	defer log.SetOutput(os.Stderr)
+	log.SetOutput(w)
+	flags := log.Flags()
+	defer log.SetFlags(flags)
+	log.SetFlags(0)
+
+	done := make(chan struct{})
+	go func() {
+		log.Println("first")
+		log.Println("second")
+		w.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("writer deadlocked while notifier logged its send error")
+	}
+
+	got := failing.messages()
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("sent %q, want [first second]", got)
+	}
+}
+
+func TestWriterBatchesLines(t *testing.T) {
+	rec := &recordNotifier{}
+	n := New()
+	n.Notifiers = append(n.Notifiers, rec)
+
+	w := n.Writer(WriterMaxLines(3), WriterInterval(time.Hour))
+	w.Write([]byte("a\nb\n"))
+	if got := rec.messages(); len(got) != 0 {
+		t.Fatalf("sent %q before batch was full", got)
+	}
+	w.Write([]byte("c\nd"))
+	w.Close()
+
+	got := rec.messages()
+	if len(got) != 2 || got[0] != strings.Join([]string{"a", "b", "c"}, "\n") || got[1] != "d" {
+		t.Fatalf("sent %q", got)
+	}
+}
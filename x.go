@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XDirectMessage 以 OAuth 1.0a 使用者身分發送私訊給指定的使用者 ID
+func (n *Notify) XDirectMessage(consumerKey, consumerSecret, accessToken, accessSecret string, recipientIDs []string) *Notify {
+	n.add(&xdm{
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+		AccessToken:    accessToken,
+		AccessSecret:   accessSecret,
+		RecipientIDs:   recipientIDs,
+	})
+	return n
+}
+
+// XDirectMessageOAuth2 使用具 dm.write 權限的 OAuth 2.0 使用者 access token
+func (n *Notify) XDirectMessageOAuth2(bearerToken string, recipientIDs []string) *Notify {
+	n.add(&xdm{
+		BearerToken:  bearerToken,
+		RecipientIDs: recipientIDs,
+	})
+	return n
+}
+
+type xdm struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	AccessToken    string
+	AccessSecret   string
+	BearerToken    string
+	RecipientIDs   []string
+}
+
+func (x *xdm) Send(client *http.Client, message string) error {
+	return x.SendRaw(client, map[string]interface{}{"text": message})
+}
+
+func (x *xdm) SendRaw(client *http.Client, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	var errs []error
+	for _, recipient := range x.RecipientIDs {
+		url := fmt.Sprintf("https://api.twitter.com/2/dm_conversations/with/%s/messages", recipient)
+		req, err := http.NewRequest("POST", url, bytes.NewReader(jsonData))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		if x.BearerToken != "" {
+			req.Header.Set("Authorization", "Bearer "+x.BearerToken)
+		} else {
+			req.Header.Set("Authorization", x.oauth1(req))
+		}
+
+		if err := request(client, req); err != nil {
+			errs = append(errs, fmt.Errorf("x dm %s: %w", recipient, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// oauth1 產生 OAuth 1.0a HMAC-SHA1 Authorization header，JSON body 不列入簽章
+func (x *xdm) oauth1(req *http.Request) string {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	oauth := map[string]string{
+		"oauth_consumer_key":     x.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_token":            x.AccessToken,
+		"oauth_version":          "1.0",
+	}
+
+	params := map[string]string{}
+	for key, value := range oauth {
+		params[key] = value
+	}
+	for key, values := range req.URL.Query() {
+		params[key] = values[0]
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		pairs = append(pairs, percentEncode(key)+"="+percentEncode(params[key]))
+	}
+
+	baseURL := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+	base := req.Method + "&" + percentEncode(baseURL) + "&" + percentEncode(strings.Join(pairs, "&"))
+
+	mac := hmac.New(sha1.New, []byte(percentEncode(x.ConsumerSecret)+"&"+percentEncode(x.AccessSecret)))
+	mac.Write([]byte(base))
+	oauth["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	keys = keys[:0]
+	for key := range oauth {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var header []string
+	for _, key := range keys {
+		header = append(header, fmt.Sprintf(`%s="%s"`, percentEncode(key), percentEncode(oauth[key])))
+	}
+
+	return "OAuth " + strings.Join(header, ", ")
+}
+
+// percentEncode RFC 3986 編碼
+func percentEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,286 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type XMPPOption func(*xmpp)
+
+// XMPPServer 指定伺服器 host:port，預設為 JID 網域的 5222 port
+func XMPPServer(address string) XMPPOption {
+	return func(x *xmpp) { x.Server = address }
+}
+
+// XMPPDirectTLS 直接以 TLS 連線（通常為 5223 port），不使用 STARTTLS
+func XMPPDirectTLS() XMPPOption {
+	return func(x *xmpp) { x.DirectTLS = true }
+}
+
+// XMPPInsecure 允許伺服器未提供 STARTTLS 時以明文登入，僅限內網測試使用
+func XMPPInsecure() XMPPOption {
+	return func(x *xmpp) { x.Insecure = true }
+}
+
+func (n *Notify) XMPP(jid, password string, recipients []string, opts ...XMPPOption) *Notify {
+	x := &xmpp{
+		JID:        jid,
+		Password:   password,
+		Recipients: recipients,
+	}
+	for _, opt := range opts {
+		opt(x)
+	}
+	if x.Server == "" {
+		x.Server = net.JoinHostPort(x.domain(), "5222")
+	}
+	n.add(x)
+	return n
+}
+
+type xmpp struct {
+	JID        string
+	Password   string
+	Recipients []string
+	Server     string
+	DirectTLS  bool
+	Insecure   bool
+}
+
+type xmppFeatures struct {
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms []string  `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms>mechanism"`
+	Session    *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-session session"`
+}
+
+func (x *xmpp) domain() string {
+	domain := x.JID
+	if _, after, ok := strings.Cut(domain, "@"); ok {
+		domain = after
+	}
+	domain, _, _ = strings.Cut(domain, "/")
+	return domain
+}
+
+func (x *xmpp) Send(_ *http.Client, message string) error {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(message))
+	body := buf.String()
+
+	var stanzas []string
+	for _, to := range x.Recipients {
+		stanzas = append(stanzas, fmt.Sprintf(
+			"<message to='%s' type='chat'><body>%s</body></message>", xmlAttr(to), body))
+	}
+
+	return x.deliver(stanzas)
+}
+
+// SendRaw 支援 {"to": JID, "body": 內容, "type": chat|normal|headline}
+func (x *xmpp) SendRaw(client *http.Client, message map[string]interface{}) error {
+	body, _ := message["body"].(string)
+	to, ok := message["to"].(string)
+	if !ok {
+		return x.Send(client, body)
+	}
+	msgType, _ := message["type"].(string)
+	if msgType == "" {
+		msgType = "chat"
+	}
+
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(body))
+	return x.deliver([]string{fmt.Sprintf(
+		"<message to='%s' type='%s'><body>%s</body></message>", xmlAttr(to), xmlAttr(msgType), buf.String())})
+}
+
+func (x *xmpp) deliver(stanzas []string) error {
+	conn, err := x.login()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for _, stanza := range stanzas {
+		if _, err := conn.Write([]byte(stanza)); err != nil {
+			return fmt.Errorf("failed to write xmpp message: %v", err)
+		}
+	}
+	conn.Write([]byte("</stream:stream>"))
+
+	return nil
+}
+
+func (x *xmpp) login() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	host, _, _ := net.SplitHostPort(x.Server)
+	tlsConfig := &tls.Config{ServerName: host}
+
+	var conn net.Conn
+	var err error
+	if x.DirectTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", x.Server, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", x.Server)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect xmpp server: %v", err)
+	}
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	fail := func(err error) (net.Conn, error) {
+		conn.Close()
+		return nil, err
+	}
+
+	decoder, features, err := x.openStream(conn)
+	if err != nil {
+		return fail(err)
+	}
+
+	if !x.DirectTLS {
+		if features.StartTLS != nil {
+			conn.Write([]byte("<starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>"))
+			element, err := xmppNextElement(decoder)
+			if err != nil {
+				return fail(err)
+			}
+			if element.Name.Local != "proceed" {
+				return fail(errors.New("xmpp server refused STARTTLS"))
+			}
+
+			tlsConn := tls.Client(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return fail(fmt.Errorf("xmpp TLS handshake failed: %v", err))
+			}
+			conn = tlsConn
+
+			decoder, features, err = x.openStream(conn)
+			if err != nil {
+				return fail(err)
+			}
+		} else if !x.Insecure {
+			return fail(errors.New("xmpp server does not offer STARTTLS"))
+		}
+	}
+
+	hasPlain := false
+	for _, mechanism := range features.Mechanisms {
+		if mechanism == "PLAIN" {
+			hasPlain = true
+		}
+	}
+	if !hasPlain {
+		return fail(fmt.Errorf("xmpp server does not support SASL PLAIN: %v", features.Mechanisms))
+	}
+
+	// SASL PLAIN: authzid \0 authcid \0 password
+	user, _, _ := strings.Cut(x.JID, "@")
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + user + "\x00" + x.Password))
+	fmt.Fprintf(conn, "<auth xmlns='urn:ietf:params:xml:ns:xmpp-sasl' mechanism='PLAIN'>%s</auth>", credentials)
+
+	element, err := xmppNextElement(decoder)
+	if err != nil {
+		return fail(err)
+	}
+	if element.Name.Local != "success" {
+		return fail(errors.New("xmpp authentication failed"))
+	}
+
+	decoder, features, err = x.openStream(conn)
+	if err != nil {
+		return fail(err)
+	}
+
+	resource := "notify"
+	if _, after, ok := strings.Cut(x.JID, "/"); ok {
+		resource = after
+	}
+	fmt.Fprintf(conn, "<iq type='set' id='bind_1'><bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'><resource>%s</resource></bind></iq>", xmlAttr(resource))
+	if err := xmppExpectResult(decoder); err != nil {
+		return fail(fmt.Errorf("xmpp resource bind failed: %v", err))
+	}
+
+	if features.Session != nil {
+		conn.Write([]byte("<iq type='set' id='sess_1'><session xmlns='urn:ietf:params:xml:ns:xmpp-session'/></iq>"))
+		if err := xmppExpectResult(decoder); err != nil {
+			return fail(fmt.Errorf("xmpp session failed: %v", err))
+		}
+	}
+
+	return conn, nil
+}
+
+func (x *xmpp) openStream(conn net.Conn) (*xml.Decoder, *xmppFeatures, error) {
+	fmt.Fprintf(conn, "<?xml version='1.0'?><stream:stream to='%s' xmlns='jabber:client' "+
+		"xmlns:stream='http://etherx.jabber.org/streams' version='1.0'>", xmlAttr(x.domain()))
+
+	decoder := xml.NewDecoder(conn)
+	element, err := xmppNextElement(decoder)
+	if err != nil {
+		return nil, nil, err
+	}
+	if element.Name.Local != "stream" {
+		return nil, nil, fmt.Errorf("unexpected xmpp element: %s", element.Name.Local)
+	}
+
+	element, err = xmppNextElement(decoder)
+	if err != nil {
+		return nil, nil, err
+	}
+	if element.Name.Local != "features" {
+		return nil, nil, fmt.Errorf("unexpected xmpp element: %s", element.Name.Local)
+	}
+
+	features := &xmppFeatures{}
+	if err := decoder.DecodeElement(features, element); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode xmpp features: %v", err)
+	}
+
+	return decoder, features, nil
+}
+
+func xmppNextElement(decoder *xml.Decoder) (*xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read xmpp stream: %v", err)
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			return &element, nil
+		}
+	}
+}
+
+func xmppExpectResult(decoder *xml.Decoder) error {
+	element, err := xmppNextElement(decoder)
+	if err != nil {
+		return err
+	}
+
+	var iq struct {
+		Type string `xml:"type,attr"`
+	}
+	if err := decoder.DecodeElement(&iq, element); err != nil {
+		return err
+	}
+	if element.Name.Local != "iq" || iq.Type != "result" {
+		return fmt.Errorf("unexpected response <%s type=%q>", element.Name.Local, iq.Type)
+	}
+
+	return nil
+}
+
+func xmlAttr(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return strings.ReplaceAll(buf.String(), "'", "&#39;")
+}
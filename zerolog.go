@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+var zerologLevels = map[string]int{
+	"trace": -1,
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+	"fatal": 4,
+	"panic": 5,
+}
+
+type ZerologOption func(*zerologWriter)
+
+// ZerologLevel 觸發通知的最低等級，預設 "warn"
+func ZerologLevel(level string) ZerologOption {
+	return func(z *zerologWriter) { z.MinLevel = zerologLevels[level] }
+}
+
+// ZerologThrottle 相同訊息在 window 內只送一次，且每個 window 最多送 burst 則，預設 1 分鐘 10 則
+func ZerologThrottle(window time.Duration, burst int) ZerologOption {
+	return func(z *zerologWriter) { z.throttle = NewThrottle(window, burst) }
+}
+
+// ZerologWriter 解析 zerolog 的 JSON 事件並轉為通知，搭配 zerolog.MultiLevelWriter 使用，例如
+//
+//	log := zerolog.New(zerolog.MultiLevelWriter(os.Stderr, n.ZerologWriter()))
+func (n *Notify) ZerologWriter(opts ...ZerologOption) io.Writer {
+	z := &zerologWriter{
+		notify:   n,
+		MinLevel: zerologLevels["warn"],
+		throttle: NewThrottle(time.Minute, 10),
+	}
+	for _, opt := range opts {
+		opt(z)
+	}
+	return z
+}
+
+type zerologWriter struct {
+	MinLevel int
+
+	notify   *Notify
+	throttle *Throttle
+}
+
+// Write 永遠回報成功，避免通知失敗影響原本的 log 輸出
+func (z *zerologWriter) Write(p []byte) (int, error) {
+	var event map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return len(p), nil
+	}
+
+	level, _ := event["level"].(string)
+	rank, ok := zerologLevels[level]
+	if !ok || rank < z.MinLevel {
+		return len(p), nil
+	}
+
+	text, _ := event["message"].(string)
+	if text == "" {
+		text, _ = event["error"].(string)
+	}
+	if !z.throttle.Allow(level + "\x00" + text) {
+		return len(p), nil
+	}
+
+	message := Message{
+		Title: text,
+		Key:   text,
+	}
+	switch {
+	case rank >= zerologLevels["fatal"]:
+		message.Severity = SeverityCritical
+	case rank == zerologLevels["error"]:
+		message.Severity = SeverityError
+	case rank == zerologLevels["warn"]:
+		message.Severity = SeverityWarning
+	}
+
+	keys := make([]string, 0, len(event))
+	for key := range event {
+		switch key {
+		case "level", "message", "time":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value string
+		switch v := event[key].(type) {
+		case string:
+			value = v
+		case json.Number:
+			value = v.String()
+		default:
+			jsonData, _ := json.Marshal(v)
+			value = string(jsonData)
+		}
+		message.Fields = append(message.Fields, Field{Name: key, Value: value})
+	}
+	if dropped := z.throttle.Dropped(); dropped > 0 {
+		message.Text = fmt.Sprintf("(%d similar messages suppressed)", dropped)
+	}
+
+	// fatal / panic 之後程式會結束，需同步送出
+	if rank >= zerologLevels["fatal"] {
+		z.notify.Send(message)
+	} else {
+		go z.notify.Send(message)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type ZohoCliqOption func(*zohocliq)
+
+// ZohoCliqBot 以指定 bot 身分發送，channel 中需已加入此 bot
+func ZohoCliqBot(name string) ZohoCliqOption {
+	return func(z *zohocliq) { z.Bot = name }
+}
+
+// ZohoCliqDomain 非美國資料中心時指定，例如 cliq.zoho.eu、cliq.zoho.in
+func ZohoCliqDomain(domain string) ZohoCliqOption {
+	return func(z *zohocliq) { z.Domain = domain }
+}
+
+// ZohoCliq 使用 webhook token (zapikey) 發送到 channel，channel 為其 unique name
+func (n *Notify) ZohoCliq(webhookToken, channel string, opts ...ZohoCliqOption) *Notify {
+	z := &zohocliq{
+		WebhookToken: webhookToken,
+		Channel:      channel,
+		Domain:       "cliq.zoho.com",
+	}
+	for _, opt := range opts {
+		opt(z)
+	}
+	n.add(z)
+	return n
+}
+
+type zohocliq struct {
+	WebhookToken string
+	Channel      string
+	Domain       string
+	Bot          string
+}
+
+func (z *zohocliq) Send(client *http.Client, message string) error {
+	return z.SendRaw(client, map[string]interface{}{"text": message})
+}
+
+func (z *zohocliq) SendMessage(client *http.Client, message Message) error {
+	payload := map[string]interface{}{
+		"text": message.Text,
+		"card": map[string]interface{}{
+			"title": fmt.Sprintf("[%s] %s", message.Severity, message.Title),
+			"theme": "modern-inline",
+		},
+	}
+
+	var slides []map[string]interface{}
+	if len(message.Fields) > 0 {
+		var data []map[string]string
+		for _, field := range message.Fields {
+			data = append(data, map[string]string{field.Name: field.Value})
+		}
+		slides = append(slides, map[string]interface{}{
+			"type":  "label",
+			"title": "Details",
+			"data":  data,
+		})
+	}
+	if len(slides) > 0 {
+		payload["slides"] = slides
+	}
+	if message.URL != "" {
+		payload["buttons"] = []map[string]interface{}{{
+			"label": "Open",
+			"type":  "+",
+			"action": map[string]interface{}{
+				"type": "open.url",
+				"data": map[string]string{"web": message.URL},
+			},
+		}}
+	}
+
+	return z.SendRaw(client, payload)
+}
+
+func (z *zohocliq) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if _, ok := message["bot"]; !ok && z.Bot != "" {
+		message["bot"] = map[string]interface{}{"name": z.Bot}
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	url := fmt.Sprintf("https://%s/api/v2/channelsbyname/%s/message?zapikey=%s",
+		z.Domain, url.PathEscape(z.Channel), url.QueryEscape(z.WebhookToken))
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return request(client, req)
+}
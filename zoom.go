@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ZoomWebhook 使用 Incoming Webhook app 的 endpoint 與 verification token
+func (n *Notify) ZoomWebhook(endpointURL, verificationToken string) *Notify {
+	n.add(&zoom{
+		EndpointURL:       endpointURL,
+		VerificationToken: verificationToken,
+	})
+	return n
+}
+
+// ZoomChatbot 以 chatbot 身分發送到指定 JID（頻道或使用者），內容支援 markdown
+func (n *Notify) ZoomChatbot(clientID, clientSecret, accountID, robotJID, toJID string) *Notify {
+	n.add(&zoom{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		AccountID:    accountID,
+		RobotJID:     robotJID,
+		ToJID:        toJID,
+	})
+	return n
+}
+
+type zoom struct {
+	EndpointURL       string
+	VerificationToken string
+
+	ClientID     string
+	ClientSecret string
+	AccountID    string
+	RobotJID     string
+	ToJID        string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func (z *zoom) Send(client *http.Client, message string) error {
+	return z.SendRaw(client, map[string]interface{}{
+		"head": map[string]interface{}{"text": subjectOf(message)},
+		"body": []map[string]interface{}{{
+			"type":                "message",
+			"text":                message,
+			"is_markdown_support": true,
+		}},
+	})
+}
+
+func (z *zoom) SendMessage(client *http.Client, message Message) error {
+	var lines []string
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("*%s*: %s", field.Name, field.Value))
+	}
+
+	body := []map[string]interface{}{{
+		"type":                "message",
+		"text":                strings.Join(lines, "\n"),
+		"is_markdown_support": true,
+	}}
+	if message.URL != "" {
+		body = append(body, map[string]interface{}{
+			"type": "message",
+			"text": "Open",
+			"link": message.URL,
+		})
+	}
+
+	return z.SendRaw(client, map[string]interface{}{
+		"head": map[string]interface{}{
+			"text":  fmt.Sprintf("[%s] %s", message.Severity, message.Title),
+			"style": map[string]interface{}{"color": severityColor(message), "bold": true},
+		},
+		"body": body,
+	})
+}
+
+// SendRaw 傳入訊息的 content 物件（head / body）
+func (z *zoom) SendRaw(client *http.Client, message map[string]interface{}) error {
+	if z.EndpointURL != "" {
+		url := z.EndpointURL
+		if !strings.Contains(url, "format=") {
+			if strings.Contains(url, "?") {
+				url += "&format=full"
+			} else {
+				url += "?format=full"
+			}
+		}
+		return z.post(client, url, z.VerificationToken, map[string]interface{}{"content": message})
+	}
+
+	token, err := z.token(client)
+	if err != nil {
+		return err
+	}
+
+	return z.post(client, "https://api.zoom.us/v2/im/chat/messages", "Bearer "+token, map[string]interface{}{
+		"robot_jid":           z.RobotJID,
+		"to_jid":              z.ToJID,
+		"account_id":          z.AccountID,
+		"is_markdown_support": true,
+		"content":             message,
+	})
+}
+
+func (z *zoom) token(client *http.Client) (string, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	if z.accessToken != "" && time.Now().Before(z.expiresAt) {
+		return z.accessToken, nil
+	}
+
+	req, err := http.NewRequest("POST", "https://zoom.us/oauth/token?grant_type=client_credentials", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.SetBasicAuth(z.ClientID, z.ClientSecret)
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := requestJSON(client, req, &result); err != nil {
+		return "", fmt.Errorf("failed to fetch zoom token: %v", err)
+	}
+
+	z.accessToken = result.AccessToken
+	z.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - 5*time.Minute)
+	return z.accessToken, nil
+}
+
+func (z *zoom) post(client *http.Client, url, authorization string, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authorization)
+
+	return request(client, req)
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (n *Notify) Zulip(siteURL, botEmail, apiKey, stream, topic string) *Notify {
+	n.add(&zulip{
+		SiteURL:  strings.TrimRight(siteURL, "/"),
+		BotEmail: botEmail,
+		APIKey:   apiKey,
+		Stream:   stream,
+		Topic:    topic,
+	})
+	return n
+}
+
+type zulip struct {
+	SiteURL  string
+	BotEmail string
+	APIKey   string
+	Stream   string
+	Topic    string
+}
+
+func (z *zulip) Send(client *http.Client, message string) error {
+	return z.SendRaw(client, map[string]interface{}{"content": message})
+}
+
+func (z *zulip) SendRaw(client *http.Client, message map[string]interface{}) error {
+	form := url.Values{}
+	for key, value := range message {
+		form.Set(key, fmt.Sprint(value))
+	}
+	if !form.Has("type") {
+		form.Set("type", "stream")
+	}
+	if !form.Has("to") {
+		form.Set("to", z.Stream)
+	}
+	if !form.Has("topic") && form.Get("type") == "stream" {
+		form.Set("topic", z.Topic)
+	}
+
+	req, err := http.NewRequest("POST", z.SiteURL+"/api/v1/messages", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(z.BotEmail, z.APIKey)
+
+	return request(client, req)
+}
+
+// SendMessage 有 Key 時以 Key 作為 topic，讓同一事件集中在同一個討論串
+func (z *zulip) SendMessage(client *http.Client, message Message) error {
+	var lines []string
+	if title := message.heading(); title != "" {
+		lines = append(lines, "**"+title+"**")
+	}
+	if message.Text != "" {
+		lines = append(lines, message.Text)
+	}
+	for _, field := range message.Fields {
+		lines = append(lines, fmt.Sprintf("* **%s**: %s", field.Name, field.Value))
+	}
+	if message.URL != "" {
+		lines = append(lines, message.URL)
+	}
+
+	topic := z.Topic
+	if message.Key != "" {
+		topic = message.Key
+	}
+
+	return z.SendRaw(client, map[string]interface{}{
+		"content": strings.Join(lines, "\n"),
+		"topic":   topic,
+	})
+}